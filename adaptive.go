@@ -0,0 +1,68 @@
+package godebouncer
+
+import "time"
+
+// ewmaAlpha is the weight of the latest interval in the exponentially weighted moving average.
+const ewmaAlpha = 0.2
+
+type ewmaDuration struct {
+	multiplier  float64
+	minDuration time.Duration
+	maxDuration time.Duration
+	average     float64
+	lastSignal  time.Time
+	// duration is the adapted wait duration, or 0 until the first interval is measured.
+	duration time.Duration
+}
+
+// WithEWMADuration makes the wait duration adapt to the cadence of the signals, and return the same instance of debouncer to use.
+// The debouncer tracks the exponentially weighted moving average of the intervals between the signals of a burst, and sets the wait duration to the multiplier of it, within the min and max durations.
+func (d *Debouncer) WithEWMADuration(multiplier float64, minDuration, maxDuration time.Duration) *Debouncer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.ewma = &ewmaDuration{multiplier: multiplier, minDuration: minDuration, maxDuration: maxDuration}
+	return d
+}
+
+// adaptDuration updates the moving average with the interval since the previous signal if it extends the pending wait duration, and updates the adapted wait duration. The wait duration configured by New() or UpdateTimeDuration() is kept. The caller must hold the mutex.
+func (d *Debouncer) adaptDuration(started bool) {
+	if d.ewma == nil {
+		return
+	}
+
+	now := d.scheduler.Now()
+	defer func() { d.ewma.lastSignal = now }()
+	if started || d.ewma.lastSignal.IsZero() {
+		return
+	}
+
+	interval := float64(now.Sub(d.ewma.lastSignal))
+	if d.ewma.average == 0 {
+		d.ewma.average = interval
+	} else {
+		d.ewma.average = ewmaAlpha*interval + (1-ewmaAlpha)*d.ewma.average
+	}
+	d.ewma.duration = min(max(time.Duration(d.ewma.multiplier*d.ewma.average), d.ewma.minDuration), d.ewma.maxDuration)
+}
+
+// WithAdaptiveWait makes the wait duration depend on the signals covered by the pending wait, and return the same instance of debouncer to use, e.g. to trigger sooner for big bursts.
+// On each signal, the wait duration is the result of adaptiveWait with the number of signals and the total size of their payloads, counting the length of string and []byte payloads. It's called with the mutex held, so it mustn't call the debouncer.
+func (d *Debouncer) WithAdaptiveWait(adaptiveWait func(count, bytes int) time.Duration) *Debouncer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.adaptiveWait = adaptiveWait
+	return d
+}
+
+// payloadSize returns the length of string and []byte payloads, and 0 for the other payloads.
+func payloadSize(data any) int {
+	switch data := data.(type) {
+	case string:
+		return len(data)
+	case []byte:
+		return len(data)
+	}
+	return 0
+}
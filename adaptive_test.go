@@ -0,0 +1,98 @@
+package godebouncer_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+func TestEWMADuration(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	countPtr, incrementCount := createIncrementCount(0)
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithEWMADuration(3, 100*time.Millisecond, 2*time.Second).WithTriggered(incrementCount)
+
+	for i := 0; i < 5; i++ {
+		debouncer.SendSignal()
+		clock.Advance(50 * time.Millisecond)
+	}
+	// The signals arrive every 50ms, so the wait duration adapts to 3 * 50ms = 150ms.
+	clock.Advance(100 * time.Millisecond)
+
+	if *countPtr != 1 {
+		t.Errorf("Expected count %d, was %d", 1, *countPtr)
+	}
+}
+
+func TestEWMADurationBounds(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	countPtr, incrementCount := createIncrementCount(0)
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithEWMADuration(3, 500*time.Millisecond, 2*time.Second).WithTriggered(incrementCount)
+
+	debouncer.SendSignal()
+	clock.Advance(10 * time.Millisecond)
+	debouncer.SendSignal()
+	clock.Advance(499 * time.Millisecond)
+
+	if *countPtr != 0 {
+		t.Errorf("Expected count %d before the min duration, was %d", 0, *countPtr)
+	}
+
+	clock.Advance(time.Millisecond)
+	if *countPtr != 1 {
+		t.Errorf("Expected count %d at the min duration, was %d", 1, *countPtr)
+	}
+}
+
+func TestAdaptiveWaitShrinksWithPayload(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	var triggeredData []any
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithAdaptiveWait(func(count, bytes int) time.Duration {
+		if count >= 3 || bytes >= 10 {
+			return 100 * time.Millisecond
+		}
+		return time.Second
+	}).WithAny(func(data any) {
+		triggeredData = append(triggeredData, data)
+	})
+
+	debouncer.SendSignalWithData("a")
+	debouncer.SendSignalWithData("b")
+	clock.Advance(500 * time.Millisecond)
+	if len(triggeredData) != 0 {
+		t.Errorf("Expected count %d, was %d", 0, len(triggeredData))
+	}
+
+	debouncer.SendSignalWithData("c")
+	clock.Advance(100 * time.Millisecond)
+	if len(triggeredData) != 1 {
+		t.Errorf("Expected count %d, was %d", 1, len(triggeredData))
+	}
+
+	debouncer.SendSignalWithData("0123456789")
+	clock.Advance(100 * time.Millisecond)
+	if len(triggeredData) != 2 {
+		t.Errorf("Expected count %d, was %d", 2, len(triggeredData))
+	}
+}
+
+func TestEWMADurationKeepsConfiguredDuration(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithEWMADuration(3, 100*time.Millisecond, 2*time.Second)
+	unregister := godebouncer.Register("ewma", debouncer)
+	defer unregister()
+
+	for i := 0; i < 5; i++ {
+		debouncer.SendSignal()
+		clock.Advance(50 * time.Millisecond)
+	}
+
+	recorder := httptest.NewRecorder()
+	godebouncer.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/debug/debouncers", nil))
+	if !strings.Contains(recorder.Body.String(), `"duration":"1s"`) {
+		t.Errorf("Expected the configured duration %s, got %s", "1s", recorder.Body.String())
+	}
+}
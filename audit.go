@@ -0,0 +1,53 @@
+package godebouncer
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+type audit struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// auditRecord is the JSON line written by WithAuditWriter() for each trigger.
+type auditRecord struct {
+	Time     time.Time `json:"time"`
+	Signals  int       `json:"signals"`
+	FirstSeq uint64    `json:"first_seq"`
+	LastSeq  uint64    `json:"last_seq"`
+	Duration string    `json:"duration"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// WithAuditWriter makes the debouncer append a JSON line to w for each trigger, with the time, the number and the sequence numbers of the coalesced signals, the duration of the triggered function and its error, and return the same instance of debouncer to use.
+// The lines are written after the triggered function returned, one at a time. The write errors are ignored.
+func (d *Debouncer) WithAuditWriter(w io.Writer) *Debouncer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.audit = &audit{w: w}
+	return d
+}
+
+// write appends the record of the trigger of the call.
+func (a *audit) write(c *call, start, end time.Time, err error) {
+	record := auditRecord{
+		Time:     start,
+		Signals:  c.count,
+		FirstSeq: c.first.Seq,
+		LastSeq:  c.last.Seq,
+		Duration: end.Sub(start).String(),
+	}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	line, _ := json.Marshal(record)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.w.Write(append(line, '\n'))
+}
@@ -0,0 +1,35 @@
+package godebouncer_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+func TestAuditWriter(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0).UTC())
+	var audit bytes.Buffer
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithAuditWriter(&audit).WithAnyResult(func(data any) (any, error) {
+		if data == "fail" {
+			return nil, errors.New("boom")
+		}
+		return nil, nil
+	})
+
+	debouncer.SendSignalWithData("a")
+	debouncer.SendSignalWithData("b")
+	clock.Advance(time.Second)
+	debouncer.SendSignalWithData("fail")
+	clock.Advance(time.Second)
+
+	expectedAudit := `{"time":"1970-01-01T00:00:01Z","signals":2,"first_seq":1,"last_seq":2,"duration":"0s"}
+{"time":"1970-01-01T00:00:02Z","signals":1,"first_seq":3,"last_seq":3,"duration":"0s","error":"boom"}
+`
+	if audit.String() != expectedAudit {
+		t.Errorf("Expected audit %s, was %s", expectedAudit, audit.String())
+	}
+}
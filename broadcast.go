@@ -0,0 +1,124 @@
+package godebouncer
+
+import (
+	"sync"
+	"time"
+)
+
+// OverflowPolicy defines what a Broadcaster does when the channel of a subscriber is full.
+type OverflowPolicy int
+
+const (
+	// OverflowDropNewest drops the new message and keeps the messages already in the channel.
+	OverflowDropNewest OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest message in the channel to make room for the new message.
+	OverflowDropOldest
+	// OverflowBlock waits until the subscriber receives a message. A slow subscriber delays the other subscribers.
+	OverflowBlock
+)
+
+// Broadcaster coalesces rapid published messages and pushes the last one to all subscribers once per wait duration.
+type Broadcaster struct {
+	debouncer   *Debouncer
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	mu     sync.Mutex
+	ch     chan any
+	policy OverflowPolicy
+	done   chan struct{}
+	closed bool
+}
+
+// NewBroadcaster creates a new instance of broadcaster which pushes a message to subscribers after the duration has elapsed since the last Publish().
+func NewBroadcaster(duration time.Duration) *Broadcaster {
+	b := &Broadcaster{subscribers: make(map[*subscriber]struct{})}
+	b.debouncer = New(duration).WithAny(b.broadcast)
+	return b
+}
+
+// Subscribe returns a channel with the buffer size receiving the broadcasted messages and a function to unsubscribe. The policy decides what to do when the channel is full.
+// With OverflowDropOldest, a size below 1 is raised to 1, since an unbuffered channel has no oldest message to drop. Unsubscribing releases a broadcast blocked on the channel by OverflowBlock.
+func (b *Broadcaster) Subscribe(size int, policy OverflowPolicy) (<-chan any, func()) {
+	if policy == OverflowDropOldest {
+		size = max(size, 1)
+	}
+	s := &subscriber{ch: make(chan any, size), policy: policy, done: make(chan struct{})}
+
+	b.mu.Lock()
+	b.subscribers[s] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return s.ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, s)
+			b.mu.Unlock()
+
+			close(s.done)
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.closed = true
+			close(s.ch)
+		})
+	}
+}
+
+// Publish sends a message to broadcast. Only the last message published during the wait duration is pushed to subscribers.
+func (b *Broadcaster) Publish(message any) {
+	b.debouncer.SendSignalWithData(message)
+}
+
+// Done returns a receive-only channel to notify the caller when the message has been pushed to all subscribers.
+func (b *Broadcaster) Done() <-chan struct{} {
+	return b.debouncer.Done()
+}
+
+func (b *Broadcaster) broadcast(message any) {
+	b.mu.Lock()
+	subscribers := make([]*subscriber, 0, len(b.subscribers))
+	for s := range b.subscribers {
+		subscribers = append(subscribers, s)
+	}
+	b.mu.Unlock()
+
+	for _, s := range subscribers {
+		s.send(message)
+	}
+}
+
+func (s *subscriber) send(message any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	switch s.policy {
+	case OverflowBlock:
+		select {
+		case s.ch <- message:
+		case <-s.done:
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case s.ch <- message:
+				return
+			default:
+			}
+			select {
+			case <-s.ch:
+			default:
+			}
+		}
+	default:
+		select {
+		case s.ch <- message:
+		default:
+		}
+	}
+}
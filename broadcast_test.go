@@ -0,0 +1,112 @@
+package godebouncer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+)
+
+func TestBroadcasterCoalescesMessages(t *testing.T) {
+	broadcaster := godebouncer.NewBroadcaster(200 * time.Millisecond)
+	first, unsubscribeFirst := broadcaster.Subscribe(1, godebouncer.OverflowDropNewest)
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := broadcaster.Subscribe(1, godebouncer.OverflowDropNewest)
+	defer unsubscribeSecond()
+
+	broadcaster.Publish("state 1")
+	time.Sleep(50 * time.Millisecond)
+	broadcaster.Publish("state 2")
+	<-broadcaster.Done()
+
+	for _, ch := range []<-chan any{first, second} {
+		if message := <-ch; message != "state 2" {
+			t.Errorf("Expected message %q, was %v", "state 2", message)
+		}
+		select {
+		case message := <-ch:
+			t.Errorf("Expected only one message, got %v", message)
+		default:
+		}
+	}
+}
+
+func TestBroadcasterOverflowPolicy(t *testing.T) {
+	broadcaster := godebouncer.NewBroadcaster(50 * time.Millisecond)
+	dropNewest, unsubscribeNewest := broadcaster.Subscribe(1, godebouncer.OverflowDropNewest)
+	defer unsubscribeNewest()
+	dropOldest, unsubscribeOldest := broadcaster.Subscribe(1, godebouncer.OverflowDropOldest)
+	defer unsubscribeOldest()
+
+	broadcaster.Publish("state 1")
+	<-broadcaster.Done()
+	broadcaster.Publish("state 2")
+	<-broadcaster.Done()
+
+	if message := <-dropNewest; message != "state 1" {
+		t.Errorf("Expected OverflowDropNewest to keep %q, was %v", "state 1", message)
+	}
+	if message := <-dropOldest; message != "state 2" {
+		t.Errorf("Expected OverflowDropOldest to keep %q, was %v", "state 2", message)
+	}
+}
+
+func TestBroadcasterUnsubscribe(t *testing.T) {
+	broadcaster := godebouncer.NewBroadcaster(50 * time.Millisecond)
+	ch, unsubscribe := broadcaster.Subscribe(1, godebouncer.OverflowBlock)
+
+	unsubscribe()
+	unsubscribe()
+	broadcaster.Publish("state")
+	<-broadcaster.Done()
+
+	if _, ok := <-ch; ok {
+		t.Error("Expected the channel to be closed after unsubscribe")
+	}
+}
+
+func TestBroadcasterUnsubscribeBlockedSubscriber(t *testing.T) {
+	broadcaster := godebouncer.NewBroadcaster(10 * time.Millisecond)
+	_, unsubscribe := broadcaster.Subscribe(0, godebouncer.OverflowBlock)
+	done := broadcaster.Done()
+
+	broadcaster.Publish("state")
+	time.Sleep(50 * time.Millisecond)
+
+	unsubscribed := make(chan struct{})
+	go func() {
+		unsubscribe()
+		_, unsubscribeOther := broadcaster.Subscribe(1, godebouncer.OverflowDropNewest)
+		unsubscribeOther()
+		close(unsubscribed)
+	}()
+
+	select {
+	case <-unsubscribed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected unsubscribe not to wait for the blocked broadcast")
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("Expected the blocked broadcast to be released by unsubscribe")
+	}
+}
+
+func TestBroadcasterUnbufferedDropOldest(t *testing.T) {
+	broadcaster := godebouncer.NewBroadcaster(10 * time.Millisecond)
+	ch, unsubscribe := broadcaster.Subscribe(0, godebouncer.OverflowDropOldest)
+	defer unsubscribe()
+	done := broadcaster.Done()
+
+	broadcaster.Publish("state")
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the broadcast to complete")
+	}
+
+	if message := <-ch; message != "state" {
+		t.Errorf("Expected message %q, was %v", "state", message)
+	}
+}
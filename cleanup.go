@@ -0,0 +1,73 @@
+package godebouncer
+
+import (
+	"runtime"
+	"sync"
+	"weak"
+)
+
+// cleanup is shared between a debouncer and its cleanup function, so it mustn't reference the debouncer.
+type cleanup struct {
+	mu            sync.Mutex
+	pending       bool
+	stopTimerFunc func() bool
+	onAbandoned   func()
+}
+
+// WithCleanup makes the debouncer stop its pending timer when it becomes unreachable, instead of invoking the triggered function on garbage state, and return the same instance of debouncer to use.
+// onAbandoned, if not nil, is called when an unreachable debouncer is cleaned up with a pending signal, e.g. to detect leaks in tests. It mustn't reference the debouncer, otherwise the debouncer never becomes unreachable.
+func (d *Debouncer) WithCleanup(onAbandoned func()) *Debouncer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.cleanup == nil {
+		d.cleanup = &cleanup{}
+		runtime.AddCleanup(d, func(c *cleanup) { c.run() }, d.cleanup)
+	}
+	d.cleanup.mu.Lock()
+	d.cleanup.onAbandoned = onAbandoned
+	d.cleanup.mu.Unlock()
+	d.cleanup.update(d.state == statePending, d.stopTimerFunc)
+	return d
+}
+
+// expireFunc returns the function called by the scheduler when the wait duration elapsed. With WithCleanup(), it only holds a weak pointer, so a pending timer doesn't keep the debouncer reachable.
+func (d *Debouncer) expireFunc(generation uint64) func() {
+	if d.cleanup == nil {
+		return func() {
+			d.expire(generation)
+		}
+	}
+
+	pointer := weak.Make(d)
+	return func() {
+		if d := pointer.Value(); d != nil {
+			d.expire(generation)
+		}
+	}
+}
+
+// update records whether a signal is pending and how to stop its timer. It does nothing without WithCleanup().
+func (c *cleanup) update(pending bool, stopTimerFunc func() bool) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pending = pending
+	c.stopTimerFunc = stopTimerFunc
+}
+
+func (c *cleanup) run() {
+	c.mu.Lock()
+	pending, onAbandoned := c.pending, c.onAbandoned
+	if pending && c.stopTimerFunc != nil {
+		c.stopTimerFunc()
+	}
+	c.mu.Unlock()
+
+	if pending && onAbandoned != nil {
+		onAbandoned()
+	}
+}
@@ -0,0 +1,55 @@
+package godebouncer_test
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+)
+
+func TestCleanupAbandonedDebouncer(t *testing.T) {
+	var triggered int32
+	abandoned := make(chan struct{})
+	func() {
+		debouncer := godebouncer.New(200 * time.Millisecond).WithCleanup(func() {
+			close(abandoned)
+		}).WithTriggered(func() {
+			atomic.AddInt32(&triggered, 1)
+		})
+		debouncer.SendSignal()
+	}()
+
+	deadline := time.After(time.Second)
+	for cleaned := false; !cleaned; {
+		runtime.GC()
+		select {
+		case <-abandoned:
+			cleaned = true
+		case <-deadline:
+			t.Fatal("Expected the abandoned debouncer to be cleaned up")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	time.Sleep(300 * time.Millisecond)
+
+	if atomic.LoadInt32(&triggered) != 0 {
+		t.Errorf("Expected the abandoned debouncer not to trigger, was triggered %d times", atomic.LoadInt32(&triggered))
+	}
+}
+
+func TestCleanupReachableDebouncer(t *testing.T) {
+	countPtr, incrementCount := createIncrementCount(0)
+	debouncer := godebouncer.New(200 * time.Millisecond).WithCleanup(func() {
+		t.Error("Expected a reachable debouncer not to be cleaned up")
+	}).WithTriggered(incrementCount)
+
+	debouncer.SendSignal()
+	runtime.GC()
+	<-debouncer.Done()
+
+	if *countPtr != 1 {
+		t.Errorf("Expected count %d, was %d", 1, *countPtr)
+	}
+}
@@ -0,0 +1,88 @@
+// Command godebounce runs a command once per quiet period of its input, with the coalesced lines.
+//
+// Usage:
+//
+//	godebounce [-wait 500ms] [-args] [-watch path]... [-interval 1s] command [arguments]
+//
+// Without -watch, godebounce reads lines from stdin. With -watch, it polls the files every interval, and each created, removed or modified file is a line, once per batch.
+// Once no line has been read for the wait duration, the command runs with the coalesced lines on its stdin, or appended to its arguments with -args. The last lines are flushed when stdin ends.
+//
+// For example, to reload a service once per burst of saves of its config:
+//
+//	godebounce -watch config.yaml systemctl reload my-service
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// paths is a repeatable flag of paths.
+type paths []string
+
+func (p *paths) String() string {
+	return strings.Join(*p, ",")
+}
+
+func (p *paths) Set(value string) error {
+	*p = append(*p, value)
+	return nil
+}
+
+type options struct {
+	wait     time.Duration
+	asArgs   bool
+	watch    paths
+	interval time.Duration
+	command  []string
+}
+
+func main() {
+	var opts options
+	flag.DurationVar(&opts.wait, "wait", 500*time.Millisecond, "quiet period before running the command")
+	flag.BoolVar(&opts.asArgs, "args", false, "append the coalesced lines to the arguments instead of writing them to the stdin of the command")
+	flag.Var(&opts.watch, "watch", "path to watch instead of reading stdin, repeatable")
+	flag.DurationVar(&opts.interval, "interval", time.Second, "interval between the polls of the watched paths")
+	flag.Parse()
+	opts.command = flag.Args()
+
+	if err := run(context.Background(), opts, os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "godebounce:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, opts options, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(opts.command) == 0 {
+		return errors.New("a command is required")
+	}
+
+	lines := readLines(stdin)
+	if len(opts.watch) > 0 {
+		lines = watch(ctx, opts.watch, opts.interval)
+	}
+
+	for batch := range coalesce(ctx, opts.wait, lines) {
+		if len(opts.watch) > 0 {
+			batch = unique(batch)
+		}
+		cmd := exec.CommandContext(ctx, opts.command[0], opts.command[1:]...)
+		if opts.asArgs {
+			cmd.Args = append(cmd.Args, batch...)
+		} else {
+			cmd.Stdin = strings.NewReader(strings.Join(batch, "\n") + "\n")
+		}
+		cmd.Stdout, cmd.Stderr = stdout, stderr
+		if err := cmd.Run(); err != nil {
+			fmt.Fprintf(stderr, "godebounce: %s: %v\n", opts.command[0], err)
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunCoalescesStdinLines(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh isn't available")
+	}
+
+	testCases := []struct {
+		name           string
+		asArgs         bool
+		command        []string
+		expectedOutput string
+	}{
+		{name: "Stdin", command: []string{"sh", "-c", "echo $(cat)"}, expectedOutput: "a b c\n"},
+		{name: "Args", asArgs: true, command: []string{"echo", "got"}, expectedOutput: "got a b c\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			opts := options{wait: 50 * time.Millisecond, asArgs: testCase.asArgs, command: testCase.command}
+
+			err := run(context.Background(), opts, strings.NewReader("a\nb\nc\n"), &stdout, &stderr)
+
+			if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if stdout.String() != testCase.expectedOutput {
+				t.Errorf("Expected output %q, was %q (stderr %q)", testCase.expectedOutput, stdout.String(), stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunWatch(t *testing.T) {
+	if _, err := exec.LookPath("echo"); err != nil {
+		t.Skip("echo isn't available")
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	var stdout, stderr bytes.Buffer
+	opts := options{wait: 100 * time.Millisecond, asArgs: true, watch: paths{path}, interval: 10 * time.Millisecond, command: []string{"echo", "changed"}}
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		os.WriteFile(path, []byte("a"), 0o644)
+		time.Sleep(20 * time.Millisecond)
+		os.WriteFile(path, []byte("ab"), 0o644)
+	}()
+	run(ctx, opts, nil, &stdout, &stderr)
+
+	expectedOutput := "changed " + path + "\n"
+	if stdout.String() != expectedOutput {
+		t.Errorf("Expected output %q, was %q (stderr %q)", expectedOutput, stdout.String(), stderr.String())
+	}
+}
+
+func TestRunWithoutCommand(t *testing.T) {
+	err := run(context.Background(), options{}, strings.NewReader(""), nil, nil)
+
+	if err == nil || err.Error() != "a command is required" {
+		t.Errorf("Expected error %q, got %v", "a command is required", err)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"iter"
+	"os"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/filewatch"
+)
+
+// coalesce yields the batches of lines once no line has been read for the wait duration, until the lines end or the context is done.
+func coalesce(ctx context.Context, wait time.Duration, lines iter.Seq[string]) iter.Seq[[]string] {
+	return func(yield func([]string) bool) {
+		for batch := range godebouncer.Coalesce(wait, lines) {
+			if ctx.Err() != nil || !yield(batch) {
+				return
+			}
+		}
+	}
+}
+
+// readLines yields the lines read from r until it ends.
+func readLines(r io.Reader) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			if !yield(scanner.Text()) {
+				return
+			}
+		}
+	}
+}
+
+// watch yields the paths which have been created, removed or modified, polling them every interval until the context is done.
+func watch(ctx context.Context, paths []string, interval time.Duration) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		last := make(map[string]os.FileInfo, len(paths))
+		for _, path := range paths {
+			last[path] = stat(path)
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			for _, path := range paths {
+				info := stat(path)
+				if filewatch.Changed(last[path], info) && !yield(path) {
+					return
+				}
+				last[path] = info
+			}
+		}
+	}
+}
+
+func stat(path string) os.FileInfo {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+	return info
+}
+
+// unique returns the lines without their repetitions, in the order of their first occurrence.
+func unique(lines []string) []string {
+	seen := make(map[string]bool, len(lines))
+	result := lines[:0]
+	for _, line := range lines {
+		if !seen[line] {
+			seen[line] = true
+			result = append(result, line)
+		}
+	}
+	return result
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// methodConfig is how a method is debounced.
+type methodConfig struct {
+	duration time.Duration
+	// key is the name of the argument to coalesce the calls per value, or empty to coalesce all the calls.
+	key string
+}
+
+type wrapper struct {
+	Package   string
+	Interface string
+	// StdImports and Imports are the import specs of the standard and the other packages used by the interface.
+	StdImports []string
+	Imports    []string
+	Methods    []method
+	Debounced  []string
+	UsesKey    bool
+}
+
+type method struct {
+	Name      string
+	Params    []param
+	Results   string
+	Debounced bool
+	Duration  string
+	// Key is the index of the argument to coalesce the calls per value, or -1.
+	Key int
+}
+
+type param struct {
+	Name     string
+	Type     string
+	Variadic bool
+	// FieldType is the type of the argument kept while the call is debounced.
+	FieldType string
+}
+
+// generate returns the source of the wrapper of the interface declared in src.
+func generate(src []byte, filename, typeName string, methods map[string]methodConfig) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	iface, err := findInterface(file, typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	w := wrapper{Package: file.Name.Name, Interface: typeName}
+	packages := map[string]bool{}
+	for _, field := range iface.Methods.List {
+		if len(field.Names) == 0 {
+			return nil, fmt.Errorf("embedded interfaces aren't supported in %s", typeName)
+		}
+		m, err := newMethod(fset, field.Names[0].Name, field.Type.(*ast.FuncType), methods)
+		if err != nil {
+			return nil, err
+		}
+		w.Methods = append(w.Methods, m)
+		if m.Debounced {
+			w.Debounced = append(w.Debounced, m.Name)
+			w.UsesKey = w.UsesKey || m.Key >= 0
+		}
+		usedPackages(field.Type, packages)
+	}
+	for name := range methods {
+		if !contains(w.Debounced, name) {
+			return nil, fmt.Errorf("method %s isn't declared in %s", name, typeName)
+		}
+	}
+	w.StdImports, w.Imports = imports(file, packages)
+
+	var buf bytes.Buffer
+	if err := wrapperTemplate.Execute(&buf, w); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+func findInterface(file *ast.File, typeName string) (*ast.InterfaceType, error) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if typeSpec.Name.Name != typeName {
+				continue
+			}
+			iface, ok := typeSpec.Type.(*ast.InterfaceType)
+			if !ok {
+				return nil, fmt.Errorf("%s isn't an interface", typeName)
+			}
+			if typeSpec.TypeParams != nil {
+				return nil, fmt.Errorf("generic interface %s isn't supported", typeName)
+			}
+			return iface, nil
+		}
+	}
+	return nil, fmt.Errorf("interface %s not found", typeName)
+}
+
+func newMethod(fset *token.FileSet, name string, funcType *ast.FuncType, methods map[string]methodConfig) (method, error) {
+	m := method{Name: name, Key: -1}
+	config, debounced := methods[name]
+
+	for _, field := range funcType.Params.List {
+		names := len(field.Names)
+		if names == 0 {
+			names = 1
+		}
+		for i := 0; i < names; i++ {
+			p := param{Name: "p" + strconv.Itoa(len(m.Params)), Type: source(fset, field.Type), FieldType: source(fset, field.Type)}
+			if ellipsis, ok := field.Type.(*ast.Ellipsis); ok {
+				p.Variadic = true
+				p.FieldType = "[]" + source(fset, ellipsis.Elt)
+			}
+			if debounced && config.key != "" && i < len(field.Names) && field.Names[i].Name == config.key {
+				m.Key = len(m.Params)
+			}
+			m.Params = append(m.Params, p)
+		}
+	}
+	if funcType.Results != nil {
+		var results []string
+		for _, field := range funcType.Results.List {
+			for i := 0; i < max(len(field.Names), 1); i++ {
+				results = append(results, source(fset, field.Type))
+			}
+		}
+		m.Results = strings.Join(results, ", ")
+		if len(results) > 1 {
+			m.Results = "(" + m.Results + ")"
+		}
+	}
+
+	if !debounced {
+		return m, nil
+	}
+	if m.Results != "" {
+		return m, fmt.Errorf("debounced method %s mustn't return results", name)
+	}
+	if config.key != "" && m.Key < 0 {
+		return m, fmt.Errorf("method %s has no argument %s", name, config.key)
+	}
+	m.Debounced = true
+	m.Duration = durationLiteral(config.duration)
+	return m, nil
+}
+
+// durationLiteral returns the Go expression of the duration in the largest unit which divides it, e.g. 500 * time.Millisecond.
+func durationLiteral(duration time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+	for _, u := range units {
+		if duration != 0 && duration%u.unit == 0 {
+			return fmt.Sprintf("%d * %s", duration/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", int64(duration))
+}
+
+func source(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, fset, node)
+	return buf.String()
+}
+
+// usedPackages collects the names of the packages referenced by the node.
+func usedPackages(node ast.Node, packages map[string]bool) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		if selector, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				packages[ident.Name] = true
+			}
+		}
+		return true
+	})
+}
+
+// imports returns the import specs of the file for the packages used by the interface, split between the standard and the other packages.
+func imports(file *ast.File, packages map[string]bool) (std []string, others []string) {
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if !packages[name] {
+			continue
+		}
+		importSpec := spec.Path.Value
+		if spec.Name != nil {
+			importSpec = spec.Name.Name + " " + spec.Path.Value
+		}
+		if strings.Contains(strings.Split(importPath, "/")[0], ".") {
+			others = append(others, importSpec)
+		} else {
+			std = append(std, importSpec)
+		}
+	}
+	return std, others
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+var wrapperTemplate = template.Must(template.New("wrapper").Parse(`// Code generated by godebouncer-gen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- if .UsesKey}}
+	"fmt"
+{{- end}}
+	"sync"
+	"time"
+{{- range .StdImports}}
+	{{.}}
+{{- end}}
+
+	"github.com/vnteamopen/godebouncer"
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+)
+
+// Debounced{{.Interface}} wraps a {{.Interface}} and debounces the calls of {{range $i, $name := .Debounced}}{{if $i}}, {{end}}{{$name}}{{end}}.
+type Debounced{{.Interface}} struct {
+	next       {{.Interface}}
+	mu         sync.Mutex
+	debouncers map[string]map[string]*godebouncer.Debouncer
+}
+
+var _ {{.Interface}} = (*Debounced{{.Interface}})(nil)
+
+// NewDebounced{{.Interface}} creates a new instance of the wrapper forwarding the calls to next.
+func NewDebounced{{.Interface}}(next {{.Interface}}) *Debounced{{.Interface}} {
+	return &Debounced{{.Interface}}{next: next, debouncers: make(map[string]map[string]*godebouncer.Debouncer)}
+}
+{{range $m := .Methods}}
+{{- if $m.Debounced}}
+type debounced{{$.Interface}}{{$m.Name}}Args struct {
+{{- range $m.Params}}
+	{{.Name}} {{.FieldType}}
+{{- end}}
+}
+
+// {{$m.Name}} is debounced: the call is forwarded once no call {{if ge $m.Key 0}}with the same key {{end}}has been made for the wait duration.
+func (w *Debounced{{$.Interface}}) {{$m.Name}}({{range $i, $p := $m.Params}}{{if $i}}, {{end}}{{$p.Name}} {{$p.Type}}{{end}}) {
+	args := debounced{{$.Interface}}{{$m.Name}}Args{ {{- range $i, $p := $m.Params}}{{if $i}}, {{end}}{{$p.Name}}: {{$p.Name}}{{end -}} }
+	w.debounce("{{$m.Name}}", {{if ge $m.Key 0}}fmt.Sprint({{(index $m.Params $m.Key).Name}}){{else}}""{{end}}, {{$m.Duration}}, args, func(data any) {
+		args := data.(debounced{{$.Interface}}{{$m.Name}}Args)
+		w.next.{{$m.Name}}({{range $i, $p := $m.Params}}{{if $i}}, {{end}}args.{{$p.Name}}{{if $p.Variadic}}...{{end}}{{end}})
+	})
+}
+{{else}}
+// {{$m.Name}} is forwarded immediately.
+func (w *Debounced{{$.Interface}}) {{$m.Name}}({{range $i, $p := $m.Params}}{{if $i}}, {{end}}{{$p.Name}} {{$p.Type}}{{end}}) {{$m.Results}} {
+	{{if $m.Results}}return {{end}}w.next.{{$m.Name}}({{range $i, $p := $m.Params}}{{if $i}}, {{end}}{{$p.Name}}{{if $p.Variadic}}...{{end}}{{end}})
+}
+{{end}}
+{{- end}}
+// FlushDebounced forwards the pending calls immediately.
+func (w *Debounced{{.Interface}}) FlushDebounced() {
+	w.mu.Lock()
+	var pending []*godebouncer.Debouncer
+	for _, debouncers := range w.debouncers {
+		for _, d := range debouncers {
+			pending = append(pending, d)
+		}
+	}
+	w.mu.Unlock()
+
+	for _, d := range pending {
+		d.Flush()
+	}
+}
+
+func (w *Debounced{{.Interface}}) debounce(method, key string, duration time.Duration, args any, forward func(any)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	debouncers, ok := w.debouncers[method]
+	if !ok {
+		debouncers = make(map[string]*godebouncer.Debouncer)
+		w.debouncers[method] = debouncers
+	}
+	d, ok := debouncers[key]
+	if !ok {
+		d = godebouncer.New(duration)
+		d.WithAny(func(args any) {
+			w.mu.Lock()
+			if debouncers[key] == d {
+				delete(debouncers, key)
+			}
+			w.mu.Unlock()
+
+			forward(args)
+		})
+		debouncers[key] = d
+	}
+	d.SendSignalWithData(args)
+}
+`))
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := os.ReadFile("testdata/repository.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := os.ReadFile("testdata/repository_debounced.go.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	generated, err := generate(src, "repository.go", "Repository", map[string]methodConfig{
+		"Save":  {duration: 500 * time.Millisecond, key: "id"},
+		"Touch": {duration: time.Second},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if string(generated) != string(expected) {
+		t.Errorf("Expected the golden file, got:\n%s", generated)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	src, err := os.ReadFile("testdata/repository.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name          string
+		typeName      string
+		methods       map[string]methodConfig
+		expectedError string
+	}{
+		{name: "UnknownInterface", typeName: "Store", methods: map[string]methodConfig{"Save": {}}, expectedError: "interface Store not found"},
+		{name: "NotAnInterface", typeName: "User", methods: map[string]methodConfig{"Save": {}}, expectedError: "User isn't an interface"},
+		{name: "UnknownMethod", typeName: "Repository", methods: map[string]methodConfig{"Delete": {}}, expectedError: "method Delete isn't declared in Repository"},
+		{name: "MethodWithResults", typeName: "Repository", methods: map[string]methodConfig{"Get": {}}, expectedError: "debounced method Get mustn't return results"},
+		{name: "UnknownKey", typeName: "Repository", methods: map[string]methodConfig{"Save": {key: "name"}}, expectedError: "method Save has no argument name"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := generate(src, "repository.go", testCase.typeName, testCase.methods)
+			if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+				t.Errorf("Expected error %q, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
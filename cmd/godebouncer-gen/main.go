@@ -0,0 +1,87 @@
+// Command godebouncer-gen generates a wrapper of an interface where the selected methods are debounced.
+//
+// Usage:
+//
+//	//go:generate godebouncer-gen -type Repository -method Save=500ms -key Save=id
+//
+// The wrapper NewDebouncedRepository(next Repository) implements Repository: the calls of Save are coalesced per value of the id argument, and only the last call of each id is forwarded to next once no call has been made for 500 milliseconds. The other methods are forwarded immediately.
+// The debounced methods mustn't return results. The wrapper is written to <type>_debounced.go next to the source file, unless -output is set.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// assignments is a repeatable flag of name=value.
+type assignments map[string]string
+
+func (a assignments) String() string {
+	return fmt.Sprint(map[string]string(a))
+}
+
+func (a assignments) Set(value string) error {
+	name, v, ok := strings.Cut(value, "=")
+	if !ok || name == "" || v == "" {
+		return fmt.Errorf("expected name=value, got %q", value)
+	}
+	a[name] = v
+	return nil
+}
+
+func main() {
+	typeName := flag.String("type", "", "name of the interface to wrap")
+	file := flag.String("file", os.Getenv("GOFILE"), "source file declaring the interface")
+	output := flag.String("output", "", "output file, <type>_debounced.go by default")
+	durations := assignments{}
+	flag.Var(durations, "method", "debounced method and its wait duration, e.g. Save=500ms, repeatable")
+	keys := assignments{}
+	flag.Var(keys, "key", "argument of a debounced method to coalesce the calls per value, e.g. Save=id, repeatable")
+	flag.Parse()
+
+	if err := run(*typeName, *file, *output, durations, keys); err != nil {
+		fmt.Fprintln(os.Stderr, "godebouncer-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(typeName, file, output string, durations, keys assignments) error {
+	if typeName == "" || file == "" {
+		return fmt.Errorf("-type and -file are required")
+	}
+	if len(durations) == 0 {
+		return fmt.Errorf("at least one -method is required")
+	}
+
+	methods := make(map[string]methodConfig, len(durations))
+	for name, value := range durations {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("method %s: %w", name, err)
+		}
+		methods[name] = methodConfig{duration: duration, key: keys[name]}
+	}
+	for name := range keys {
+		if _, ok := methods[name]; !ok {
+			return fmt.Errorf("key of method %s which isn't debounced", name)
+		}
+	}
+
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	generated, err := generate(src, file, typeName, methods)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		output = filepath.Join(filepath.Dir(file), strings.ToLower(typeName)+"_debounced.go")
+	}
+	return os.WriteFile(output, generated, 0o644)
+}
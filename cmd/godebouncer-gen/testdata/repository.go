@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"io"
+)
+
+type User struct {
+	ID   string
+	Name string
+}
+
+type Repository interface {
+	Get(ctx context.Context, id string) (User, error)
+	Save(ctx context.Context, id string, user User)
+	Touch(ids ...string)
+	Export(w io.Writer) error
+}
@@ -0,0 +1,32 @@
+// Command godebouncerd is a sidecar HTTP server exposing named debouncers through a small JSON API, for the services which can't use the package.
+//
+// Usage:
+//
+//	godebouncerd [-addr :8080] [-webhook-timeout 10s]
+//
+// API:
+//
+//	PUT    /debouncers/{name}           creates or replaces a debouncer: {"wait": "500ms", "payload": "last", "webhook": "http://..."}
+//	DELETE /debouncers/{name}           deletes a debouncer, cancelling its pending signal
+//	POST   /debouncers/{name}/signals   sends a signal, the body is the JSON payload, if any
+//	GET    /debouncers/{name}/triggers  waits for the next trigger, at most ?timeout=30s, then returns 204 No Content, as when the debouncer is replaced or deleted
+//	GET    /debug/debouncers            renders the state of the debouncers
+//
+// The payload policy is "last" (default), "first" or "all" to receive the array of the coalesced payloads. The request bodies are limited to 1 MiB. Each trigger is posted to the webhook, if any, within the webhook timeout, and returned to the long-polling callers as {"name": ..., "time": ..., "payload": ...}.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	webhookTimeout := flag.Duration("webhook-timeout", 10*time.Second, "timeout of the webhook requests")
+	flag.Parse()
+
+	log.Printf("godebouncerd: listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, newServer(&http.Client{Timeout: *webhookTimeout}).handler()))
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+)
+
+// policy is the body of PUT /debouncers/{name}.
+type policy struct {
+	Wait    string `json:"wait"`
+	Payload string `json:"payload"`
+	Webhook string `json:"webhook"`
+}
+
+// trigger is posted to the webhook and returned to the long-polling callers.
+type trigger struct {
+	Name    string          `json:"name"`
+	Time    time.Time       `json:"time"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// maxBodySize is the maximum size of the request bodies.
+const maxBodySize = 1 << 20
+
+type server struct {
+	mu         sync.Mutex
+	client     *http.Client
+	debouncers map[string]*named
+}
+
+// named is a debouncer created through the API.
+type named struct {
+	debouncer  *godebouncer.Debouncer
+	unregister func()
+	mu         sync.Mutex
+	next       *nextTrigger
+	released   bool
+}
+
+// nextTrigger is resolved by the next trigger of a debouncer, or released when the debouncer is replaced or deleted.
+type nextTrigger struct {
+	done     chan struct{}
+	trigger  trigger
+	released bool
+}
+
+func newServer(client *http.Client) *server {
+	return &server{client: client, debouncers: make(map[string]*named)}
+}
+
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /debouncers/{name}", s.create)
+	mux.HandleFunc("DELETE /debouncers/{name}", s.delete)
+	mux.HandleFunc("POST /debouncers/{name}/signals", s.signal)
+	mux.HandleFunc("GET /debouncers/{name}/triggers", s.triggers)
+	mux.Handle("GET /debug/debouncers", godebouncer.Handler())
+	return mux
+}
+
+func (s *server) create(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	var p policy
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&p); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	wait, err := time.ParseDuration(p.Wait)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	n := &named{next: &nextTrigger{done: make(chan struct{})}}
+	n.debouncer = godebouncer.New(wait).WithAny(func(payload any) {
+		s.fire(name, n, p.Webhook, payload)
+	})
+	switch p.Payload {
+	case "", "last":
+	case "first":
+		n.debouncer.WithPayloadPolicy(godebouncer.PayloadFirstWins)
+	case "all":
+		n.debouncer.WithTransform(func(payload any) any {
+			return []json.RawMessage{payload.(json.RawMessage)}
+		}).WithReducer(func(pending, payload any) any {
+			return append(pending.([]json.RawMessage), payload.([]json.RawMessage)...)
+		})
+	default:
+		http.Error(w, fmt.Sprintf("unknown payload policy %q", p.Payload), http.StatusBadRequest)
+		return
+	}
+	n.unregister = godebouncer.Register(name, n.debouncer)
+
+	s.mu.Lock()
+	previous := s.debouncers[name]
+	s.debouncers[name] = n
+	s.mu.Unlock()
+
+	if previous != nil {
+		previous.debouncer.Close()
+		previous.release()
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *server) delete(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	n, ok := s.debouncers[r.PathValue("name")]
+	delete(s.debouncers, r.PathValue("name"))
+	s.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	n.unregister()
+	n.debouncer.Close()
+	n.release()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *server) signal(w http.ResponseWriter, r *http.Request) {
+	n, ok := s.lookup(r.PathValue("name"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var payload json.RawMessage
+	if len(bytes.TrimSpace(body)) > 0 {
+		if !json.Valid(body) {
+			http.Error(w, "the payload isn't valid JSON", http.StatusBadRequest)
+			return
+		}
+		payload = body
+	}
+
+	if err := n.debouncer.SendSignalWithData(payload); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *server) triggers(w http.ResponseWriter, r *http.Request) {
+	n, ok := s.lookup(r.PathValue("name"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	timeout := 30 * time.Second
+	if value := r.URL.Query().Get("timeout"); value != "" {
+		var err error
+		if timeout, err = time.ParseDuration(value); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	n.mu.Lock()
+	next := n.next
+	n.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-next.done:
+		if next.released {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(next.trigger)
+	case <-timer.C:
+		w.WriteHeader(http.StatusNoContent)
+	case <-r.Context().Done():
+	}
+}
+
+func (s *server) lookup(name string) (*named, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, ok := s.debouncers[name]
+	return n, ok
+}
+
+// fire resolves the long-polling callers with the trigger, and posts it to the webhook if any.
+func (s *server) fire(name string, n *named, webhook string, payload any) {
+	t := trigger{Name: name, Time: time.Now()}
+	switch payload := payload.(type) {
+	case json.RawMessage:
+		t.Payload = payload
+	case []json.RawMessage:
+		t.Payload, _ = json.Marshal(payload)
+	}
+
+	n.mu.Lock()
+	if !n.released {
+		next := n.next
+		n.next = &nextTrigger{done: make(chan struct{})}
+		next.trigger = t
+		close(next.done)
+	}
+	n.mu.Unlock()
+
+	if webhook == "" {
+		return
+	}
+	body, _ := json.Marshal(t)
+	resp, err := s.client.Post(webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		log.Printf("godebouncerd: webhook of %s: %v", name, err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		log.Printf("godebouncerd: webhook of %s: %s", name, resp.Status)
+	}
+}
+
+// release returns the long-polling callers of a replaced or deleted debouncer with no trigger, so they poll the new one.
+func (n *named) release() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.released {
+		return
+	}
+	n.released = true
+	n.next.released = true
+	close(n.next.done)
+}
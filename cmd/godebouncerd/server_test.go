@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func request(t *testing.T, method, url, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestServerLongPoll(t *testing.T) {
+	testCases := []struct {
+		name            string
+		payloadPolicy   string
+		expectedPayload string
+	}{
+		{name: "Last", payloadPolicy: "last", expectedPayload: `{"id":3}`},
+		{name: "First", payloadPolicy: "first", expectedPayload: `{"id":1}`},
+		{name: "All", payloadPolicy: "all", expectedPayload: `[{"id":1},{"id":2},{"id":3}]`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(newServer(http.DefaultClient).handler())
+			defer server.Close()
+
+			resp := request(t, "PUT", server.URL+"/debouncers/orders", `{"wait": "50ms", "payload": "`+testCase.payloadPolicy+`"}`)
+			if resp.StatusCode != http.StatusNoContent {
+				t.Fatalf("Expected status %d, was %d", http.StatusNoContent, resp.StatusCode)
+			}
+
+			triggers := make(chan *http.Response)
+			go func() {
+				triggers <- request(t, "GET", server.URL+"/debouncers/orders/triggers?timeout=1s", "")
+			}()
+			time.Sleep(10 * time.Millisecond)
+			for _, payload := range []string{`{"id":1}`, `{"id":2}`, `{"id":3}`} {
+				request(t, "POST", server.URL+"/debouncers/orders/signals", payload)
+			}
+
+			resp = <-triggers
+			var got trigger
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Name != "orders" || string(got.Payload) != testCase.expectedPayload {
+				t.Errorf("Expected the trigger of orders with %s, was %s with %s", testCase.expectedPayload, got.Name, got.Payload)
+			}
+		})
+	}
+}
+
+func TestServerWebhook(t *testing.T) {
+	received := make(chan string, 1)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- string(body)
+	}))
+	defer webhook.Close()
+	server := httptest.NewServer(newServer(http.DefaultClient).handler())
+	defer server.Close()
+
+	request(t, "PUT", server.URL+"/debouncers/reindex", `{"wait": "20ms", "webhook": "`+webhook.URL+`"}`)
+	request(t, "POST", server.URL+"/debouncers/reindex/signals", "")
+	request(t, "POST", server.URL+"/debouncers/reindex/signals", `"shard-1"`)
+
+	select {
+	case body := <-received:
+		if !strings.Contains(body, `"name":"reindex"`) || !strings.Contains(body, `"payload":"shard-1"`) {
+			t.Errorf("Unexpected webhook body %s", body)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the trigger to be posted to the webhook")
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	server := httptest.NewServer(newServer(http.DefaultClient).handler())
+	defer server.Close()
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "InvalidWait", method: "PUT", path: "/debouncers/a", body: `{"wait": "soon"}`, expectedStatus: http.StatusBadRequest},
+		{name: "UnknownPolicy", method: "PUT", path: "/debouncers/a", body: `{"wait": "1s", "payload": "random"}`, expectedStatus: http.StatusBadRequest},
+		{name: "UnknownDebouncer", method: "POST", path: "/debouncers/unknown/signals", expectedStatus: http.StatusNotFound},
+		{name: "DeleteUnknown", method: "DELETE", path: "/debouncers/unknown", expectedStatus: http.StatusNotFound},
+		{name: "LongPollTimeout", method: "GET", path: "/debouncers/b/triggers?timeout=10ms", expectedStatus: http.StatusNoContent},
+		{name: "InvalidPayload", method: "POST", path: "/debouncers/b/signals", body: "{", expectedStatus: http.StatusBadRequest},
+		{name: "PayloadTooLarge", method: "POST", path: "/debouncers/b/signals", body: `"` + strings.Repeat("a", maxBodySize) + `"`, expectedStatus: http.StatusRequestEntityTooLarge},
+	}
+	request(t, "PUT", server.URL+"/debouncers/b", `{"wait": "1s"}`)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resp := request(t, testCase.method, server.URL+testCase.path, testCase.body)
+			if resp.StatusCode != testCase.expectedStatus {
+				t.Errorf("Expected status %d, was %d", testCase.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestServerReplaceReleasesLongPoll(t *testing.T) {
+	testCases := []struct {
+		name   string
+		method string
+		body   string
+	}{
+		{name: "Replace", method: "PUT", body: `{"wait": "1s"}`},
+		{name: "Delete", method: "DELETE"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(newServer(http.DefaultClient).handler())
+			defer server.Close()
+
+			request(t, "PUT", server.URL+"/debouncers/orders", `{"wait": "1s"}`)
+			triggers := make(chan *http.Response)
+			go func() {
+				triggers <- request(t, "GET", server.URL+"/debouncers/orders/triggers?timeout=5s", "")
+			}()
+			time.Sleep(10 * time.Millisecond)
+			request(t, testCase.method, server.URL+"/debouncers/orders", testCase.body)
+
+			select {
+			case resp := <-triggers:
+				if resp.StatusCode != http.StatusNoContent {
+					t.Errorf("Expected status %d, was %d", http.StatusNoContent, resp.StatusCode)
+				}
+			case <-time.After(time.Second):
+				t.Error("Expected the long-polling caller to be released")
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package godebouncer
+
+import (
+	"sync"
+	"time"
+)
+
+// CommandDebouncer produces debounced commands for Elm-style update loops, e.g. search-as-you-type in Bubble Tea, where a command is a function run in the background returning a message to the update loop.
+// With M being tea.Msg, the commands are tea.Cmd.
+type CommandDebouncer[M any] struct {
+	mu         sync.Mutex
+	duration   time.Duration
+	scheduler  Scheduler
+	superseded chan struct{}
+}
+
+// NewCommandDebouncer creates a new instance of command debouncer with the wait duration.
+func NewCommandDebouncer[M any](duration time.Duration) *CommandDebouncer[M] {
+	return &CommandDebouncer[M]{duration: duration, scheduler: timeScheduler{}}
+}
+
+// WithScheduler replaces the scheduler used to wait for the duration, and return the same instance of command debouncer to use.
+func (c *CommandDebouncer[M]) WithScheduler(scheduler Scheduler) *CommandDebouncer[M] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.scheduler = scheduler
+	return c
+}
+
+// Cmd returns a command which returns msg once the wait duration elapsed since this call. If Cmd() is called again meanwhile, the command returns the zero value of M as soon as possible instead, which Bubble Tea ignores.
+func (c *CommandDebouncer[M]) Cmd(msg M) func() M {
+	c.mu.Lock()
+	if c.superseded != nil {
+		close(c.superseded)
+	}
+	superseded := make(chan struct{})
+	c.superseded = superseded
+	elapsed := make(chan struct{})
+	stop := c.scheduler.AfterFunc(c.duration, func() { close(elapsed) })
+	c.mu.Unlock()
+
+	return func() M {
+		var zero M
+		select {
+		case <-superseded:
+			stop()
+			return zero
+		case <-elapsed:
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if c.superseded != superseded {
+			return zero
+		}
+		c.superseded = nil
+		return msg
+	}
+}
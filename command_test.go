@@ -0,0 +1,31 @@
+package godebouncer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+type searchMsg struct {
+	query string
+}
+
+func TestCommandDebouncer(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	commands := godebouncer.NewCommandDebouncer[any](300 * time.Millisecond).WithScheduler(clock)
+
+	first := commands.Cmd(searchMsg{query: "g"})
+	clock.Advance(100 * time.Millisecond)
+	second := commands.Cmd(searchMsg{query: "go"})
+
+	if msg := first(); msg != nil {
+		t.Errorf("Expected no message from a superseded command, was %v", msg)
+	}
+
+	clock.Advance(300 * time.Millisecond)
+	if msg := second(); msg != (searchMsg{query: "go"}) {
+		t.Errorf("Expected message %v, was %v", searchMsg{query: "go"}, msg)
+	}
+}
@@ -0,0 +1,50 @@
+package godebouncer
+
+import "sync"
+
+// ConsecutiveDebouncer is the count-based alternative of StateDebouncer: the callback is only invoked when a new value has been set K consecutive times, independent of the wall time.
+type ConsecutiveDebouncer[T comparable] struct {
+	k        int
+	onChange func(oldValue, newValue T)
+	mu       sync.Mutex
+	value    T
+	target   T
+	count    int
+}
+
+// NewConsecutiveDebouncer creates a new instance of consecutive debouncer starting with the initial value, which calls onChange with the old and new values when a new value has been set k consecutive times.
+func NewConsecutiveDebouncer[T comparable](initial T, k int, onChange func(oldValue, newValue T)) *ConsecutiveDebouncer[T] {
+	return &ConsecutiveDebouncer[T]{k: k, onChange: onChange, value: initial, target: initial}
+}
+
+// Set reports the current raw value. A value different from the debounced one is counted, and the count restarts when another value is set.
+func (c *ConsecutiveDebouncer[T]) Set(value T) {
+	c.mu.Lock()
+	if value == c.value {
+		c.target, c.count = value, 0
+		c.mu.Unlock()
+		return
+	}
+	if value == c.target {
+		c.count++
+	} else {
+		c.target, c.count = value, 1
+	}
+	if c.count < c.k {
+		c.mu.Unlock()
+		return
+	}
+
+	oldValue := c.value
+	c.value, c.count = value, 0
+	c.mu.Unlock()
+
+	c.onChange(oldValue, value)
+}
+
+// Value returns the debounced value.
+func (c *ConsecutiveDebouncer[T]) Value() T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.value
+}
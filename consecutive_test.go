@@ -0,0 +1,27 @@
+package godebouncer_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/vnteamopen/godebouncer"
+)
+
+func TestConsecutiveDebouncer(t *testing.T) {
+	var changes []string
+	consecutive := godebouncer.NewConsecutiveDebouncer(0, 3, func(oldValue, newValue int) {
+		changes = append(changes, fmt.Sprintf("%d->%d", oldValue, newValue))
+	})
+
+	for _, reading := range []int{1, 1, 0, 1, 1, 2, 2, 2, 2, 1, 1, 1} {
+		consecutive.Set(reading)
+	}
+
+	expectedChanges := "[0->2 2->1]"
+	if fmt.Sprint(changes) != expectedChanges {
+		t.Errorf("Expected changes %s, was %s", expectedChanges, fmt.Sprint(changes))
+	}
+	if consecutive.Value() != 1 {
+		t.Errorf("Expected value %d, was %d", 1, consecutive.Value())
+	}
+}
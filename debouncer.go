@@ -1,7 +1,9 @@
 package godebouncer
 
 import (
+	"context"
 	"errors"
+	"math/rand/v2"
 	"sync"
 	"time"
 )
@@ -11,22 +13,123 @@ const (
 	ErrorTypeIncorrectSendSignal = "You are using SendSignal with something setup WithAny"
 	// ErrorTypeIncorrectSendSignalWithAny  if you call SendSignalWithAny on something you configured WithTriggered
 	ErrorTypeIncorrectSendSignalWithAny = "You are using SendSignalWithAny with something setup WithTriggered"
+	// ErrorTypeTriggerStuck if the triggered function has been running longer than the threshold configured WithStuckThreshold
+	ErrorTypeTriggerStuck = "The triggered function has been running longer than the stuck threshold"
+	// ErrorTypeTriggerFailing if the triggered function failed consecutively as many times as the threshold configured WithFailureThreshold
+	ErrorTypeTriggerFailing = "The triggered function is failing repeatedly"
+	// ErrorTypePendingLimit if the pending wait covers as many signals as the limit configured WithPendingLimit
+	ErrorTypePendingLimit = "The pending signals reached the limit"
+	// ErrorTypeClosed if you send a signal to a debouncer which has been closed
+	ErrorTypeClosed = "The debouncer is closed"
+	// ErrorTypeCancelled if the signal you are waiting for has been cancelled before the triggered function was invoked
+	ErrorTypeCancelled = "The signal has been cancelled"
+	// ErrorTypeFiltered if the payload you send has been rejected by the filter configured WithFilter
+	ErrorTypeFiltered = "The payload has been rejected by the filter"
 )
 
 // Debouncer main struct for debouncer package
 type Debouncer struct {
-	timeDuration     time.Duration
-	timer            *time.Timer
-	triggeredFunc    func()
-	triggeredAnyFunc func(any)
-	isAny            bool
-	mu               sync.Mutex
-	done             chan struct{}
+	timeDuration        time.Duration
+	scheduler           Scheduler
+	stopTimerFunc       func() bool
+	triggeredFunc       func()
+	triggeredAnyFunc    func(any)
+	triggeredResultFunc func(any) (any, error)
+	isAny               bool
+	mu                  sync.Mutex
+	done                chan struct{}
+	fireC               chan struct{}
+	stuckThreshold      time.Duration
+	firingSince         []time.Time
+	failureThreshold    int
+	failures            int
+	pendingLimit        int
+	onWaitStart         func()
+	onWaitEnd           func(fired bool)
+	onFiring            func(first, last Signal)
+	loadShedder         func() bool
+	fireGate            func() bool
+	onShed              func()
+	generation          uint64
+	seq                 uint64
+	pending             *call
+	state               state
+	running             int
+	idle                chan struct{}
+	closed              bool
+	stopContextFunc     func() bool
+	stagger             time.Duration
+	stats               Stats
+	rearm               *rearm
+	resultCacheTTL      time.Duration
+	cachedFlight        *flight
+	cachedAt            time.Time
+	events              chan Event
+	filter              *filter
+	transform           func(any) any
+	ewma                *ewmaDuration
+	adaptiveWait        func(count, bytes int) time.Duration
+	payloadPolicy       PayloadPolicy
+	reduce              func(pending, data any) any
+	delayRule           DelayRule
+	cleanup             *cleanup
+	lockFile            *lockFile
+	audit               *audit
+}
+
+// state is where the debouncer is in its lifecycle: Idle -> Pending -> Firing -> Idle. All transitions happen with the mutex held.
+type state int
+
+const (
+	// stateIdle when there is no pending signal and no running triggered function.
+	stateIdle state = iota
+	// statePending when a signal waits for the wait duration to elapse. A triggered function of a previous signal may still be running.
+	statePending
+	// stateFiring when the triggered function is running and there is no pending signal.
+	stateFiring
+)
+
+// Stats is a snapshot of the counters of a debouncer.
+type Stats struct {
+	// Signals is the number of accepted signals.
+	Signals uint64
+	// Coalesced is the number of signals replaced by a later signal within the wait duration.
+	Coalesced uint64
+	// Cancelled is the number of pending signals cancelled by Cancel().
+	Cancelled uint64
+	// Fired is the number of times the triggered function has been invoked.
+	Fired uint64
+	// Failed is the number of times the triggered function attached by WithAnyResult() returned an error.
+	Failed uint64
+	// Pending reports whether a signal is waiting for the wait duration to elapse.
+	Pending bool
+	// LastFired is the time the triggered function was last invoked.
+	LastFired time.Time
 }
 
 // New creates a new instance of debouncer. Each instance of debouncer works independent, concurrency with different wait duration.
 func New(duration time.Duration) *Debouncer {
-	return &Debouncer{timeDuration: duration, triggeredFunc: func() {}, triggeredAnyFunc: func(any) {}}
+	return &Debouncer{timeDuration: duration, scheduler: timeScheduler{}, triggeredFunc: func() {}, triggeredAnyFunc: func(any) {}}
+}
+
+// NewWithContext creates a new instance of debouncer bound to the context, like New(duration).WithContext(ctx, false).
+func NewWithContext(ctx context.Context, duration time.Duration) *Debouncer {
+	return New(duration).WithContext(ctx, false)
+}
+
+// WithContext binds the lifetime of the debouncer to the context, and return the same instance of debouncer to use. When the context is done, the debouncer is closed: further signals return an error, and the pending signal is cancelled, or flushed if flushOnDone is true.
+// The callers waiting for a cancelled signal, e.g. in SendSignalAndWait(), are released with ctx.Err().
+func (d *Debouncer) WithContext(ctx context.Context, flushOnDone bool) *Debouncer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopContextFunc != nil {
+		d.stopContextFunc()
+	}
+	d.stopContextFunc = context.AfterFunc(ctx, func() {
+		d.close(ctx.Err(), flushOnDone)
+	})
+	return d
 }
 
 // WithTriggered attached a triggered function to debouncer instance and return the same instance of debouncer to use.
@@ -39,6 +142,7 @@ func (d *Debouncer) WithTriggered(triggeredFunc func()) *Debouncer {
 // WithAny attached a triggered function to debouncer instance and return the same instance of debouncer to use.
 func (d *Debouncer) WithAny(triggeredFunc func(any)) *Debouncer {
 	d.triggeredAnyFunc = triggeredFunc
+	d.triggeredResultFunc = nil
 	d.isAny = true
 	return d
 }
@@ -49,18 +153,11 @@ func (d *Debouncer) SendSignal() (err error) {
 		return errors.New(ErrorTypeIncorrectSendSignalWithAny)
 	}
 
-	d.mu.Lock()
-	defer d.mu.Unlock()
-
-	d.Cancel()
-	d.timer = time.AfterFunc(d.timeDuration, func() {
+	_, err = d.signal(nil, func() (any, error) {
 		d.triggeredFunc()
-		if d.done != nil {
-			close(d.done)
-		}
-		d.done = make(chan struct{})
+		return nil, nil
 	})
-	return nil
+	return err
 }
 
 // SendSignalWithData makes an action that notifies to invoke the triggered function after a wait duration.
@@ -68,18 +165,355 @@ func (d *Debouncer) SendSignalWithData(anyVar any) (err error) {
 	if !d.isAny {
 		return errors.New(ErrorTypeIncorrectSendSignal)
 	}
+
+	_, err = d.signal(anyVar, d.callAny(anyVar))
+	return err
+}
+
+// SendSignalWithMetadata makes the same action as SendSignalWithData, and returns the metadata of the accepted signal. Together with WithOnFiring(), the sequence number tells which trigger covered this signal.
+func (d *Debouncer) SendSignalWithMetadata(anyVar any) (Signal, error) {
+	if !d.isAny {
+		return Signal{}, errors.New(ErrorTypeIncorrectSendSignal)
+	}
+
+	c, err := d.signal(anyVar, d.callAny(anyVar))
+	if err != nil {
+		return Signal{}, err
+	}
+	return c.last, nil
+}
+
+// signal (re)arms the timer to invoke the triggered function after a wait duration. It returns the call of this signal, whose flight is shared by all the signals covered by the same trigger.
+func (d *Debouncer) signal(data any, invoke func() (any, error)) (*call, error) {
+	return d.signalWithDelay(data, 0, invoke)
+}
+
+// signalWithDelay is signal() with the wait duration requested for this signal, or 0 for the wait duration of the debouncer.
+func (d *Debouncer) signalWithDelay(data any, delay time.Duration, invoke func() (any, error)) (*call, error) {
 	d.mu.Lock()
-	defer d.mu.Unlock()
+	if d.closed {
+		d.mu.Unlock()
+		return nil, errors.New(ErrorTypeClosed)
+	}
+	lockFile, filter, transform, scheduler := d.lockFile, d.filter, d.transform, d.scheduler
+	d.mu.Unlock()
+
+	if filter != nil && d.isAny && !filter.accept(data) {
+		return nil, d.reject()
+	}
+	if transform != nil && d.isAny {
+		data = transform(data)
+		invoke = d.callAny(data)
+	}
+	if lockFile != nil {
+		lockFile.signal(scheduler.Now())
+	}
+
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return nil, errors.New(ErrorTypeClosed)
+	}
+	d.seq++
+	c := &call{data: data, invoke: invoke, last: Signal{Seq: d.seq, ReceivedAt: d.scheduler.Now()}}
+	if delay > 0 {
+		c.delay = d.combineDelay(delay, c.last.ReceivedAt)
+	}
+	if d.state == statePending {
+		c.flight, c.first = d.pending.flight, d.pending.first
+		c.count, c.bytes = d.pending.count, d.pending.bytes
+		d.retainPayload(c, d.pending)
+	} else {
+		c.flight, c.first = newFlight(), c.last
+	}
+	c.count++
+	c.bytes += payloadSize(data)
+	started := !d.cancelPending()
+	d.adaptDuration(started)
+	d.stats.Signals++
+	if !started {
+		d.stats.Coalesced++
+	}
+	d.arm(c)
+	if started {
+		d.emit(EventArmed, c, nil)
+	} else {
+		d.emit(EventExtended, c, nil)
+	}
+	onWaitStart := d.onWaitStart
+	d.mu.Unlock()
+
+	if started && onWaitStart != nil {
+		onWaitStart()
+	}
+	return c, nil
+}
+
+// call is a pending invocation of the triggered function.
+type call struct {
+	data   any
+	invoke func() (any, error)
+	flight *flight
+	rearms int
+	// first and last are the first and last signals covered by the call.
+	first Signal
+	last  Signal
+	// count and bytes are the number and the size of the payloads of the signals covered by the call.
+	count int
+	bytes int
+	// delay is the wait duration requested by SendSignalWithDelay(), or 0 for the wait duration of the debouncer. wait is the effective wait duration the call has been armed with, including the stagger. deadline is when the wait duration elapses.
+	delay    time.Duration
+	wait     time.Duration
+	deadline time.Time
+}
+
+// Signal is the metadata of an accepted signal.
+type Signal struct {
+	// Seq is the sequence number of the signal, increasing monotonically from 1 for each debouncer.
+	Seq uint64
+	// ReceivedAt is the time the signal has been accepted.
+	ReceivedAt time.Time
+}
 
-	d.Cancel()
-	d.timer = time.AfterFunc(d.timeDuration, func() {
-		d.triggeredAnyFunc(anyVar)
-		if d.done != nil {
-			close(d.done)
+// arm schedules the call of the triggered function after the wait duration. The caller must hold the mutex.
+func (d *Debouncer) arm(c *call) {
+	duration := d.timeDuration
+	if d.ewma != nil && d.ewma.duration > 0 {
+		duration = d.ewma.duration
+	}
+	if d.adaptiveWait != nil {
+		duration = d.adaptiveWait(c.count, c.bytes)
+	}
+	if c.delay > 0 {
+		duration = c.delay
+	}
+	c.wait = duration + d.stagger
+	d.armAfter(c, duration)
+}
+
+// armAfter schedules the call of the triggered function after the duration, plus the stagger. The caller must hold the mutex.
+func (d *Debouncer) armAfter(c *call, duration time.Duration) {
+	d.generation++
+	generation := d.generation
+	d.pending = c
+	c.deadline = d.scheduler.Now().Add(duration + d.stagger)
+	d.stopTimerFunc = d.scheduler.AfterFunc(duration+d.stagger, d.expireFunc(generation))
+	d.setState(statePending)
+}
+
+// expire is called when the wait duration elapsed. It transitions Pending to Firing unless Cancel() or a new signal won the race, or defers the triggered function for another wait duration if the load shedder reports overload.
+// It also defers the triggered function while the fire gate is closed. With WithLockFile(), it also defers the triggered function if another process sent a signal within the wait duration of the pending call, and drops the pending signal if another process already triggered for it.
+func (d *Debouncer) expire(generation uint64) {
+	d.mu.Lock()
+	if d.state != statePending || d.generation != generation {
+		d.mu.Unlock()
+		return
+	}
+	loadShedder, onShed, fireGate, lockFile, duration, scheduler := d.loadShedder, d.onShed, d.fireGate, d.lockFile, d.pending.wait, d.scheduler
+	d.mu.Unlock()
+
+	shed := loadShedder != nil && loadShedder()
+	gated := !shed && fireGate != nil && !fireGate()
+	decision, remaining := lockFileFire, time.Duration(0)
+	if lockFile != nil && !shed && !gated {
+		decision, remaining = lockFile.expire(scheduler.Now(), duration)
+	}
+
+	d.mu.Lock()
+	if d.state != statePending || d.generation != generation {
+		d.mu.Unlock()
+		return
+	}
+	c := d.pending
+	if shed {
+		d.arm(c)
+		d.mu.Unlock()
+		if onShed != nil {
+			onShed()
 		}
-		d.done = make(chan struct{})
-	})
-	return nil
+		return
+	}
+	if gated {
+		d.arm(c)
+		d.mu.Unlock()
+		return
+	}
+	if decision == lockFileDefer {
+		d.armAfter(c, remaining)
+		d.mu.Unlock()
+		return
+	}
+	if decision == lockFileSkip {
+		d.cancelPending()
+		onWaitEnd := d.onWaitEnd
+		d.mu.Unlock()
+		c.flight.resolve(nil, nil)
+		if onWaitEnd != nil {
+			onWaitEnd(false)
+		}
+		return
+	}
+	d.startFiring()
+	d.mu.Unlock()
+
+	d.fire(c)
+}
+
+// cancelPending transitions Pending to Firing or Idle, stops the timer and reports whether a pending signal was cancelled. The caller must hold the mutex.
+func (d *Debouncer) cancelPending() bool {
+	if d.state != statePending {
+		return false
+	}
+	d.stopTimerFunc()
+	d.generation++
+	if d.running > 0 {
+		d.setState(stateFiring)
+	} else {
+		d.setState(stateIdle)
+	}
+	return true
+}
+
+// startFiring transitions Pending to Firing. The caller must hold the mutex, then call fire().
+func (d *Debouncer) startFiring() {
+	d.running++
+	d.setState(stateFiring)
+}
+
+// setState transitions to the state and updates the idle channel. The caller must hold the mutex.
+func (d *Debouncer) setState(s state) {
+	d.state = s
+	d.updateIdle()
+	d.cleanup.update(s == statePending, d.stopTimerFunc)
+}
+
+// updateIdle opens the idle channel when the debouncer leaves Idle, and closes it when it's back to Idle. The caller must hold the mutex.
+func (d *Debouncer) updateIdle() {
+	busy := d.state != stateIdle
+	if busy && d.idle == nil {
+		d.idle = make(chan struct{})
+	}
+	if !busy && d.idle != nil {
+		close(d.idle)
+		d.idle = nil
+	}
+}
+
+// fire invokes the triggered function, resolves the flight, and notifies the callers waiting on Done(). The caller must call startFiring() beforehand.
+// If the triggered function fails and WithRearmOnFailure() is set, the call is re-armed with the same data instead, until the re-arms are exhausted.
+func (d *Debouncer) fire(c *call) {
+	d.mu.Lock()
+	start := d.scheduler.Now()
+	d.firingSince = append(d.firingSince, start)
+	d.stats.Fired++
+	d.stats.LastFired = start
+	d.emit(EventFiring, c, nil)
+	if d.fireC != nil {
+		select {
+		case d.fireC <- struct{}{}:
+		default:
+		}
+	}
+	onWaitEnd, onFiring := d.onWaitEnd, d.onFiring
+	d.mu.Unlock()
+
+	if onWaitEnd != nil {
+		onWaitEnd(true)
+	}
+	if onFiring != nil {
+		onFiring(c.first, c.last)
+	}
+
+	result, err := c.invoke()
+
+	d.mu.Lock()
+	end, audit := d.scheduler.Now(), d.audit
+	if err != nil {
+		d.stats.Failed++
+		d.emit(EventFailed, c, err)
+	} else {
+		d.emit(EventFired, c, nil)
+	}
+	rearmed, onRearm := d.rearmOnFailure(c, err)
+	if !rearmed {
+		c.flight.resolve(result, err)
+		d.cacheResult(c.flight)
+	}
+	d.endFiring(start)
+	if err != nil {
+		d.failures++
+	} else {
+		d.failures = 0
+	}
+	d.running--
+	if d.running == 0 && d.state == stateFiring {
+		d.setState(stateIdle)
+	}
+	if d.done != nil {
+		close(d.done)
+	}
+	d.done = make(chan struct{})
+	d.mu.Unlock()
+
+	if audit != nil {
+		audit.write(c, start, end, err)
+	}
+	if onRearm != nil {
+		onRearm()
+	}
+}
+
+// WithScheduler replaces the scheduler used to wait for the duration, and return the same instance of debouncer to use. It's mostly useful to drive the debouncer with a virtual clock in tests.
+func (d *Debouncer) WithScheduler(scheduler Scheduler) *Debouncer {
+	d.scheduler = scheduler
+	return d
+}
+
+// WithStagger delays the trigger of this instance by a random offset picked once within the window, and return the same instance of debouncer to use.
+// Many debouncers sharing the same wait duration then spread their triggers across the window instead of expiring at the same time.
+func (d *Debouncer) WithStagger(window time.Duration) *Debouncer {
+	d.stagger = 0
+	if window > 0 {
+		d.stagger = rand.N(window)
+	}
+	return d
+}
+
+// WithOnWaitStart attached a function called when a new wait duration starts, i.e. the first SendSignal() since the last trigger or Cancel(), and return the same instance of debouncer to use.
+func (d *Debouncer) WithOnWaitStart(onWaitStart func()) *Debouncer {
+	d.onWaitStart = onWaitStart
+	return d
+}
+
+// WithOnWaitEnd attached a function called when the wait duration ends, and return the same instance of debouncer to use. The fired argument is true if the triggered function is about to be invoked, false if the wait was cancelled.
+func (d *Debouncer) WithOnWaitEnd(onWaitEnd func(fired bool)) *Debouncer {
+	d.onWaitEnd = onWaitEnd
+	return d
+}
+
+// WithOnFiring attached a function called before each invocation of the triggered function with the first and the last signals covered by the trigger, and return the same instance of debouncer to use.
+func (d *Debouncer) WithOnFiring(onFiring func(first, last Signal)) *Debouncer {
+	d.onFiring = onFiring
+	return d
+}
+
+// WithLoadShedder attached a function consulted when the wait duration elapsed, and return the same instance of debouncer to use. If it returns true, the process is considered overloaded and the triggered function is deferred for another wait duration with the same data.
+func (d *Debouncer) WithLoadShedder(loadShedder func() bool) *Debouncer {
+	d.loadShedder = loadShedder
+	return d
+}
+
+// WithFireGate attached a function consulted when the wait duration elapsed, and return the same instance of debouncer to use. If it returns false, e.g. on the replicas which aren't the leader, the triggered function is deferred for another wait duration with the same data.
+// All the replicas accept signals and keep their pending data, so the replica which becomes the leader triggers for the signals it received.
+func (d *Debouncer) WithFireGate(fireGate func() bool) *Debouncer {
+	d.fireGate = fireGate
+	return d
+}
+
+// WithOnShed attached a function called each time the triggered function is deferred by the load shedder, and return the same instance of debouncer to use.
+func (d *Debouncer) WithOnShed(onShed func()) *Debouncer {
+	d.onShed = onShed
+	return d
 }
 
 // Do run the signalFunc() and call SendSignal() after all. The signalFunc() and SendSignal() function run sequentially.
@@ -95,10 +529,73 @@ func (d *Debouncer) DoAny(signalFunc func(any), anyVar any) {
 }
 
 // Cancel the timer from the last function SendSignal(). The scheduled triggered function is cancelled and doesn't invoke.
-func (d *Debouncer) Cancel() {
-	if d.timer != nil {
-		d.timer.Stop()
+// It returns true if Cancel() won: the pending triggered function will never start. It returns false if there was no pending signal, or if the wait duration already elapsed, then the triggered function runs to completion.
+func (d *Debouncer) Cancel() bool {
+	return d.cancel(errors.New(ErrorTypeCancelled))
+}
+
+// cancel cancels the pending signal and releases the callers waiting for it with the cause.
+func (d *Debouncer) cancel(cause error) bool {
+	d.mu.Lock()
+	c := d.pending
+	cancelled := d.cancelPending()
+	if cancelled {
+		d.stats.Cancelled++
+		d.emit(EventCancelled, c, nil)
+	}
+	onWaitEnd := d.onWaitEnd
+	d.mu.Unlock()
+
+	if cancelled {
+		c.flight.resolve(nil, cause)
+	}
+	if cancelled && onWaitEnd != nil {
+		onWaitEnd(false)
+	}
+	return cancelled
+}
+
+// Flush invokes the pending triggered function immediately instead of waiting for the wait duration. It returns after the triggered function finished, and does nothing if there is no pending signal.
+func (d *Debouncer) Flush() {
+	d.mu.Lock()
+	if d.state != statePending {
+		d.mu.Unlock()
+		return
+	}
+	d.stopTimerFunc()
+	d.generation++
+	d.startFiring()
+	c, lockFile, scheduler := d.pending, d.lockFile, d.scheduler
+	d.mu.Unlock()
+
+	if lockFile != nil {
+		lockFile.flush(scheduler.Now())
+	}
+	d.fire(c)
+}
+
+// Close cancels the pending signal and closes the debouncer. Further SendSignal() and SendSignalWithData() return an error. A running triggered function isn't interrupted.
+func (d *Debouncer) Close() {
+	d.close(errors.New(ErrorTypeCancelled), false)
+}
+
+// close closes the debouncer, then flushes the pending signal if flush is true, or cancels it with the cause.
+func (d *Debouncer) close(cause error, flush bool) {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return
+	}
+	d.closed = true
+	if d.stopContextFunc != nil {
+		d.stopContextFunc()
+	}
+	d.mu.Unlock()
+
+	if flush {
+		d.Flush()
 	}
+	d.cancel(cause)
 }
 
 // UpdateTriggeredFunc replaces triggered function.
@@ -109,17 +606,131 @@ func (d *Debouncer) UpdateTriggeredFunc(newTriggeredFunc func()) {
 // UpdateAnyFunc replaces triggered function.
 func (d *Debouncer) UpdateAnyFunc(newTriggeredFunc func(any)) {
 	d.triggeredAnyFunc = newTriggeredFunc
+	d.triggeredResultFunc = nil
 }
 
 // UpdateTimeDuration replaces the waiting time duration. You need to call a SendSignal() again to trigger a new timer with a new waiting time duration.
 func (d *Debouncer) UpdateTimeDuration(newTimeDuration time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
 	d.timeDuration = newTimeDuration
 }
 
 // Done returns a receive-only channel to notify the caller when the triggered func has been executed.
 func (d *Debouncer) Done() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
 	if d.done == nil {
 		d.done = make(chan struct{})
 	}
 	return d.done
 }
+
+// C returns a receive-only channel which receives a value each time the wait duration elapsed, like the channel of a time.Timer. It's an alternative to the triggered function for event loops which handle the trigger in their own select.
+// The channel has a buffer of one: if a value is still unread when the wait duration elapses again, the triggers are coalesced into the unread value.
+func (d *Debouncer) C() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.fireC == nil {
+		d.fireC = make(chan struct{}, 1)
+	}
+	return d.fireC
+}
+
+// WaitOutstanding blocks until there is no pending signal and no running triggered function, including the triggers deferred by the load shedder. It returns ctx.Err() if the context is done first.
+func (d *Debouncer) WaitOutstanding(ctx context.Context) error {
+	d.mu.Lock()
+	idle := d.idle
+	d.mu.Unlock()
+
+	if idle == nil {
+		return nil
+	}
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats returns a snapshot of the counters of the debouncer.
+func (d *Debouncer) Stats() Stats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stats := d.stats
+	stats.Pending = d.state == statePending
+	return stats
+}
+
+// WithStuckThreshold makes Healthy() report an error when the triggered function runs longer than the threshold, and return the same instance of debouncer to use.
+func (d *Debouncer) WithStuckThreshold(threshold time.Duration) *Debouncer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stuckThreshold = threshold
+	return d
+}
+
+// WithFailureThreshold makes Healthy() report an error when the triggered function attached by WithAnyResult() failed consecutively as many times as the threshold, and return the same instance of debouncer to use.
+func (d *Debouncer) WithFailureThreshold(threshold int) *Debouncer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.failureThreshold = threshold
+	return d
+}
+
+// WithPendingLimit makes Healthy() report an error when the pending wait covers as many signals as the limit, e.g. when a steady stream of signals keeps postponing the trigger, and return the same instance of debouncer to use.
+func (d *Debouncer) WithPendingLimit(limit int) *Debouncer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.pendingLimit = limit
+	return d
+}
+
+// Healthy returns nil if the debouncer is healthy, otherwise an error describing the unhealthy condition.
+func (d *Debouncer) Healthy() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if oldest, ok := d.oldestFiring(); ok && d.stuckThreshold > 0 && d.scheduler.Now().Sub(oldest) > d.stuckThreshold {
+		return errors.New(ErrorTypeTriggerStuck)
+	}
+	if d.failureThreshold > 0 && d.failures >= d.failureThreshold {
+		return errors.New(ErrorTypeTriggerFailing)
+	}
+	if d.pendingLimit > 0 && d.state == statePending && d.pending.count >= d.pendingLimit {
+		return errors.New(ErrorTypePendingLimit)
+	}
+	return nil
+}
+
+// oldestFiring returns the start time of the oldest running triggered function. The caller must hold the mutex.
+func (d *Debouncer) oldestFiring() (time.Time, bool) {
+	if len(d.firingSince) == 0 {
+		return time.Time{}, false
+	}
+	oldest := d.firingSince[0]
+	for _, since := range d.firingSince[1:] {
+		if since.Before(oldest) {
+			oldest = since
+		}
+	}
+	return oldest, true
+}
+
+// endFiring removes the start time of a triggered function which returned. The caller must hold the mutex.
+func (d *Debouncer) endFiring(start time.Time) {
+	for i, since := range d.firingSince {
+		if since.Equal(start) {
+			d.firingSince = append(d.firingSince[:i], d.firingSince[i+1:]...)
+			return
+		}
+	}
+}
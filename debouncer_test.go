@@ -1,11 +1,14 @@
 package godebouncer_test
 
 import (
+	"context"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
 )
 
 func Example() {
@@ -343,3 +346,382 @@ func TestDoneHangIfBeingCalledTwice(t *testing.T) {
 	case <-time.After(time.Second):
 	}
 }
+
+func TestOnWaitStartAndEnd(t *testing.T) {
+	var events []string
+	debouncer := godebouncer.New(200 * time.Millisecond).WithTriggered(func() {
+		events = append(events, "trigger")
+	}).WithOnWaitStart(func() {
+		events = append(events, "start")
+	}).WithOnWaitEnd(func(fired bool) {
+		events = append(events, fmt.Sprintf("end %t", fired))
+	})
+	expectedEvents := "[start end false start end true trigger]"
+
+	debouncer.SendSignal()
+	debouncer.SendSignal()
+	debouncer.Cancel()
+	debouncer.Cancel()
+
+	debouncer.SendSignal()
+	time.Sleep(50 * time.Millisecond)
+	debouncer.SendSignal()
+	<-debouncer.Done()
+
+	if fmt.Sprint(events) != expectedEvents {
+		t.Errorf("Expected events %s, was %s", expectedEvents, fmt.Sprint(events))
+	}
+}
+
+func TestLoadShedderDefersTrigger(t *testing.T) {
+	var triggeredData any
+	var shedCount int32
+	debouncer := godebouncer.New(100 * time.Millisecond).WithAny(func(data any) {
+		triggeredData = data
+	}).WithLoadShedder(func() bool {
+		return atomic.LoadInt32(&shedCount) < 2
+	}).WithOnShed(func() {
+		atomic.AddInt32(&shedCount, 1)
+	})
+	expectedShedCount := int32(2)
+
+	debouncer.SendSignalWithData("retained")
+	<-debouncer.Done()
+
+	if atomic.LoadInt32(&shedCount) != expectedShedCount {
+		t.Errorf("Expected shed count %d, was %d", expectedShedCount, atomic.LoadInt32(&shedCount))
+	}
+	if triggeredData != "retained" {
+		t.Errorf("Expected data %q, was %v", "retained", triggeredData)
+	}
+}
+
+func TestFlush(t *testing.T) {
+	countPtr, incrementCount := createIncrementCount(0)
+	debouncer := godebouncer.New(time.Hour).WithTriggered(incrementCount)
+	expectedCounter := int(1)
+
+	debouncer.Flush()
+	debouncer.SendSignal()
+	debouncer.Flush()
+	debouncer.Flush()
+
+	if *countPtr != expectedCounter {
+		t.Errorf("Expected count %d, was %d", expectedCounter, *countPtr)
+	}
+}
+
+func TestDebounceWithScheduler(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	countPtr, incrementCount := createIncrementCount(0)
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithTriggered(incrementCount)
+
+	debouncer.SendSignal()
+	clock.Advance(900 * time.Millisecond)
+	debouncer.SendSignal()
+	clock.Advance(900 * time.Millisecond)
+
+	if *countPtr != 0 {
+		t.Errorf("Expected count %d, was %d", 0, *countPtr)
+	}
+
+	clock.Advance(100 * time.Millisecond)
+
+	if *countPtr != 1 {
+		t.Errorf("Expected count %d, was %d", 1, *countPtr)
+	}
+}
+
+// FuzzDebouncer explores interleavings of SendSignal, Cancel, Flush, UpdateTimeDuration and expiry on a virtual clock, and compares the number of triggers with a reference model.
+func FuzzDebouncer(f *testing.F) {
+	f.Add([]byte{0, 3, 0, 3, 3})
+	f.Add([]byte{0, 1, 3, 0, 4, 3})
+	f.Add([]byte{0, 2, 0, 0x13, 0x23, 0x33})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		clock := virtualtime.New(time.Unix(0, 0))
+		fired := 0
+		debouncer := godebouncer.New(100 * time.Millisecond).WithScheduler(clock).WithTriggered(func() {
+			fired++
+		})
+
+		expectedFired, pending, duration := 0, false, 100*time.Millisecond
+		var deadline time.Time
+		for _, op := range ops {
+			arg := time.Duration(op>>3) * 10 * time.Millisecond
+			switch op % 5 {
+			case 0:
+				debouncer.SendSignal()
+				pending, deadline = true, clock.Now().Add(duration)
+			case 1:
+				debouncer.Cancel()
+				pending = false
+			case 2:
+				debouncer.UpdateTimeDuration(arg)
+				duration = arg
+			case 3:
+				clock.Advance(arg)
+				if pending && !deadline.After(clock.Now()) {
+					expectedFired++
+					pending = false
+				}
+			case 4:
+				debouncer.Flush()
+				if pending {
+					expectedFired++
+					pending = false
+				}
+			}
+
+			if fired != expectedFired {
+				t.Fatalf("After ops %v: expected %d triggers, was %d", ops, expectedFired, fired)
+			}
+		}
+	})
+}
+
+func TestWaitOutstanding(t *testing.T) {
+	countPtr, incrementCount := createIncrementCount(0)
+	debouncer := godebouncer.New(100 * time.Millisecond).WithTriggered(func() {
+		time.Sleep(100 * time.Millisecond)
+		incrementCount()
+	})
+	expectedCounter := int(1)
+
+	if err := debouncer.WaitOutstanding(context.Background()); err != nil {
+		t.Errorf("Expected no error when nothing is outstanding, got %v", err)
+	}
+
+	debouncer.SendSignal()
+	if err := debouncer.WaitOutstanding(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if *countPtr != expectedCounter {
+		t.Errorf("Expected count %d, was %d", expectedCounter, *countPtr)
+	}
+}
+
+func TestWaitOutstandingContextDone(t *testing.T) {
+	debouncer := godebouncer.New(time.Hour)
+	debouncer.SendSignal()
+	defer debouncer.Cancel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if err := debouncer.WaitOutstanding(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected error %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestNewWithContext(t *testing.T) {
+	countPtr, incrementCount := createIncrementCount(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	debouncer := godebouncer.NewWithContext(ctx, 200*time.Millisecond).WithTriggered(incrementCount)
+	expectedCounter := int(0)
+
+	if err := debouncer.SendSignal(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	cancel()
+	time.Sleep(400 * time.Millisecond)
+
+	if *countPtr != expectedCounter {
+		t.Errorf("Expected count %d, was %d", expectedCounter, *countPtr)
+	}
+	err := debouncer.SendSignal()
+	if err == nil || err.Error() != godebouncer.ErrorTypeClosed {
+		t.Errorf("Expected error %q, got %v", godebouncer.ErrorTypeClosed, err)
+	}
+}
+
+func TestWithContextFlushOnDone(t *testing.T) {
+	countPtr, incrementCount := createIncrementCount(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	debouncer := godebouncer.New(time.Hour).WithContext(ctx, true).WithTriggered(incrementCount)
+
+	debouncer.SendSignal()
+	done := debouncer.Done()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the pending signal to be flushed when the context is done")
+	}
+
+	if *countPtr != 1 {
+		t.Errorf("Expected count %d, was %d", 1, *countPtr)
+	}
+	err := debouncer.SendSignal()
+	if err == nil || err.Error() != godebouncer.ErrorTypeClosed {
+		t.Errorf("Expected error %q, got %v", godebouncer.ErrorTypeClosed, err)
+	}
+}
+
+func TestWithContextReleasesWaiters(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	debouncer := godebouncer.New(time.Hour).WithContext(ctx, false).WithAny(func(any) {})
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	_, err := debouncer.SendSignalAndWait(context.Background(), "data")
+
+	if err != context.Canceled {
+		t.Errorf("Expected error %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestStagger(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	fireTimes := map[time.Time]bool{}
+	for i := 0; i < 100; i++ {
+		debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithStagger(time.Second).WithTriggered(func() {
+			fireTimes[clock.Now()] = true
+		})
+		debouncer.SendSignal()
+	}
+
+	clock.Advance(time.Second - time.Nanosecond)
+	if len(fireTimes) != 0 {
+		t.Errorf("Expected no trigger before the wait duration, was %d", len(fireTimes))
+	}
+
+	clock.Advance(time.Second)
+	if clock.Pending() != 0 {
+		t.Errorf("Expected all triggers within the stagger window, %d pending", clock.Pending())
+	}
+	if len(fireTimes) < 2 {
+		t.Errorf("Expected triggers spread across the stagger window, was %d distinct times", len(fireTimes))
+	}
+}
+
+func TestStats(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock)
+
+	debouncer.SendSignal()
+	debouncer.SendSignal()
+	debouncer.Cancel()
+	debouncer.SendSignal()
+	debouncer.SendSignal()
+
+	stats := debouncer.Stats()
+	expectedStats := godebouncer.Stats{Signals: 4, Coalesced: 2, Cancelled: 1, Pending: true}
+	if stats != expectedStats {
+		t.Errorf("Expected stats %+v, was %+v", expectedStats, stats)
+	}
+
+	clock.Advance(time.Second)
+
+	stats = debouncer.Stats()
+	expectedStats = godebouncer.Stats{Signals: 4, Coalesced: 2, Cancelled: 1, Fired: 1, LastFired: time.Unix(1, 0)}
+	if stats != expectedStats {
+		t.Errorf("Expected stats %+v, was %+v", expectedStats, stats)
+	}
+}
+
+// expiredScheduler simulates the race where the timer already expired when Cancel() is called: stopping always fails and the caller runs the expiry.
+type expiredScheduler struct {
+	expire func()
+}
+
+func (s *expiredScheduler) Now() time.Time {
+	return time.Now()
+}
+
+func (s *expiredScheduler) AfterFunc(_ time.Duration, f func()) func() bool {
+	s.expire = f
+	return func() bool { return false }
+}
+
+func TestCancelWinsRaceWithExpiry(t *testing.T) {
+	scheduler := &expiredScheduler{}
+	countPtr, incrementCount := createIncrementCount(0)
+	debouncer := godebouncer.New(time.Second).WithScheduler(scheduler).WithTriggered(incrementCount)
+
+	debouncer.SendSignal()
+	if !debouncer.Cancel() {
+		t.Error("Expected Cancel() to win before the expiry started")
+	}
+	scheduler.expire()
+
+	if *countPtr != 0 {
+		t.Errorf("Expected count %d, was %d", 0, *countPtr)
+	}
+}
+
+func TestCancelLosesRaceWithExpiry(t *testing.T) {
+	release := make(chan struct{})
+	countPtr, incrementCount := createIncrementCount(0)
+	debouncer := godebouncer.New(50 * time.Millisecond).WithTriggered(func() {
+		<-release
+		incrementCount()
+	})
+
+	debouncer.SendSignal()
+	time.Sleep(100 * time.Millisecond)
+	if debouncer.Cancel() {
+		t.Error("Expected Cancel() to lose once the triggered function started")
+	}
+	close(release)
+	<-debouncer.Done()
+
+	if *countPtr != 1 {
+		t.Errorf("Expected count %d, was %d", 1, *countPtr)
+	}
+}
+
+func TestFireChannel(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock)
+	fired := debouncer.C()
+
+	debouncer.SendSignal()
+	clock.Advance(500 * time.Millisecond)
+	debouncer.SendSignal()
+	clock.Advance(500 * time.Millisecond)
+	select {
+	case <-fired:
+		t.Error("Expected no value before the wait duration elapsed")
+	default:
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	debouncer.SendSignal()
+	clock.Advance(time.Second)
+
+	count := 0
+	for len(fired) > 0 {
+		<-fired
+		count++
+	}
+	if count != 1 {
+		t.Errorf("Expected count %d, was %d", 1, count)
+	}
+}
+
+func TestFireGateDefersTriggerUntilLeader(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	leader := false
+	var triggeredData []any
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithFireGate(func() bool {
+		return leader
+	}).WithAny(func(data any) {
+		triggeredData = append(triggeredData, data)
+	})
+
+	debouncer.SendSignalWithData("retained")
+	clock.Advance(3 * time.Second)
+	if len(triggeredData) != 0 {
+		t.Errorf("Expected count %d, was %d", 0, len(triggeredData))
+	}
+
+	leader = true
+	clock.Advance(time.Second)
+	if fmt.Sprint(triggeredData) != "[retained]" {
+		t.Errorf("Expected data %q, was %v", "[retained]", triggeredData)
+	}
+}
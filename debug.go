@@ -0,0 +1,90 @@
+package godebouncer
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var registry = struct {
+	mu         sync.Mutex
+	debouncers map[string]*Debouncer
+}{debouncers: map[string]*Debouncer{}}
+
+// Register makes the debouncer rendered by Handler() under the name, replacing any debouncer registered with the same name. It returns a function which unregisters the debouncer.
+func Register(name string, d *Debouncer) (unregister func()) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	registry.debouncers[name] = d
+	return func() {
+		registry.mu.Lock()
+		defer registry.mu.Unlock()
+
+		if registry.debouncers[name] == d {
+			delete(registry.debouncers, name)
+		}
+	}
+}
+
+// debugInfo is the state of a debouncer rendered by Handler().
+type debugInfo struct {
+	Duration  string    `json:"duration"`
+	Stagger   string    `json:"stagger"`
+	Closed    bool      `json:"closed"`
+	Pending   bool      `json:"pending"`
+	Running   int       `json:"running"`
+	Signals   uint64    `json:"signals"`
+	Coalesced uint64    `json:"coalesced"`
+	Cancelled uint64    `json:"cancelled"`
+	Fired     uint64    `json:"fired"`
+	Failed    uint64    `json:"failed"`
+	LastFired time.Time `json:"last_fired"`
+	Healthy   string    `json:"healthy"`
+}
+
+// Handler returns an http.Handler rendering the config, the state and the counters of all the debouncers registered by Register() as JSON, keyed by name. It's meant to be mounted under e.g. /debug/debouncers.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		registry.mu.Lock()
+		debouncers := make(map[string]*Debouncer, len(registry.debouncers))
+		for name, d := range registry.debouncers {
+			debouncers[name] = d
+		}
+		registry.mu.Unlock()
+
+		infos := make(map[string]debugInfo, len(debouncers))
+		for name, d := range debouncers {
+			infos[name] = d.debugInfo()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(infos)
+	})
+}
+
+func (d *Debouncer) debugInfo() debugInfo {
+	healthy := "ok"
+	if err := d.Healthy(); err != nil {
+		healthy = err.Error()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return debugInfo{
+		Duration:  d.timeDuration.String(),
+		Stagger:   d.stagger.String(),
+		Closed:    d.closed,
+		Pending:   d.state == statePending,
+		Running:   d.running,
+		Signals:   d.stats.Signals,
+		Coalesced: d.stats.Coalesced,
+		Cancelled: d.stats.Cancelled,
+		Fired:     d.stats.Fired,
+		Failed:    d.stats.Failed,
+		LastFired: d.stats.LastFired,
+		Healthy:   healthy,
+	}
+}
@@ -0,0 +1,50 @@
+package godebouncer_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+func TestHandler(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock)
+	unregister := godebouncer.Register("reload", debouncer)
+	defer unregister()
+
+	debouncer.SendSignal()
+	debouncer.SendSignal()
+	clock.Advance(time.Second)
+	debouncer.SendSignal()
+
+	recorder := httptest.NewRecorder()
+	godebouncer.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/debug/debouncers", nil))
+
+	var infos map[string]struct {
+		Duration string `json:"duration"`
+		Pending  bool   `json:"pending"`
+		Signals  uint64 `json:"signals"`
+		Fired    uint64 `json:"fired"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &infos); err != nil {
+		t.Fatalf("Expected JSON, got %v: %s", err, recorder.Body.String())
+	}
+	info, ok := infos["reload"]
+	if !ok {
+		t.Fatalf("Expected the registered debouncer, got %s", recorder.Body.String())
+	}
+	if info.Duration != "1s" || !info.Pending || info.Signals != 3 || info.Fired != 1 {
+		t.Errorf("Unexpected state of the debouncer: %+v", info)
+	}
+
+	unregister()
+	recorder = httptest.NewRecorder()
+	godebouncer.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/debug/debouncers", nil))
+	if body := recorder.Body.String(); body != "{}\n" {
+		t.Errorf("Expected no debouncer after unregister, got %s", body)
+	}
+}
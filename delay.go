@@ -0,0 +1,54 @@
+package godebouncer
+
+import (
+	"errors"
+	"time"
+)
+
+// DelayRule tells how the wait duration requested by SendSignalWithDelay() is combined with the remaining wait duration of the pending signal.
+type DelayRule int
+
+const (
+	// DelayReplace waits for the requested duration, it's the default.
+	DelayReplace DelayRule = iota
+	// DelayMax waits for the longest of the requested duration and the remaining wait duration, so a signal never shortens the pending wait.
+	DelayMax
+	// DelayMin waits for the shortest of the requested duration and the remaining wait duration, so a signal never lengthens the pending wait.
+	DelayMin
+)
+
+// WithDelayRule sets how the wait duration requested by SendSignalWithDelay() is combined with the remaining wait duration of the pending signal, and return the same instance of debouncer to use.
+func (d *Debouncer) WithDelayRule(rule DelayRule) *Debouncer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.delayRule = rule
+	return d
+}
+
+// SendSignalWithDelay makes the same action as SendSignalWithData, but (re)arms the timer with the delay instead of the wait duration of the debouncer, combined with the remaining wait duration of the pending signal by the delay rule.
+// It's useful when some events warrant a longer settle time than others on the same debouncer.
+// A delay <= 0 requests no duration of its own: the signal waits for the wait duration of the debouncer, as with SendSignalWithData().
+func (d *Debouncer) SendSignalWithDelay(delay time.Duration, anyVar any) error {
+	if !d.isAny {
+		return errors.New(ErrorTypeIncorrectSendSignal)
+	}
+
+	_, err := d.signalWithDelay(anyVar, delay, d.callAny(anyVar))
+	return err
+}
+
+// combineDelay returns the wait duration of a signal requesting the delay at now. The caller must hold the mutex.
+func (d *Debouncer) combineDelay(delay time.Duration, now time.Time) time.Duration {
+	if d.state != statePending {
+		return delay
+	}
+	remaining := max(d.pending.deadline.Sub(now)-d.stagger, time.Nanosecond)
+	switch d.delayRule {
+	case DelayMax:
+		return max(delay, remaining)
+	case DelayMin:
+		return min(delay, remaining)
+	}
+	return delay
+}
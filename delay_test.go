@@ -0,0 +1,76 @@
+package godebouncer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+func TestSendSignalWithDelay(t *testing.T) {
+	testCases := []struct {
+		name          string
+		rule          godebouncer.DelayRule
+		expectedFired time.Duration
+	}{
+		// The first signal waits 3s, the second signal at 1s requests 1s.
+		{name: "Replace", rule: godebouncer.DelayReplace, expectedFired: 2 * time.Second},
+		{name: "Max", rule: godebouncer.DelayMax, expectedFired: 3 * time.Second},
+		{name: "Min", rule: godebouncer.DelayMin, expectedFired: 2 * time.Second},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			start := time.Unix(0, 0)
+			clock := virtualtime.New(start)
+			var fired time.Duration
+			debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithDelayRule(testCase.rule).WithAny(func(any) {
+				fired = clock.Now().Sub(start)
+			})
+
+			debouncer.SendSignalWithDelay(3*time.Second, "slow")
+			clock.Advance(time.Second)
+			debouncer.SendSignalWithDelay(time.Second, "fast")
+			clock.Advance(5 * time.Second)
+
+			if fired != testCase.expectedFired {
+				t.Errorf("Expected fired at %v, was %v", testCase.expectedFired, fired)
+			}
+		})
+	}
+}
+
+func TestSendSignalWithDelayMinShortens(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := virtualtime.New(start)
+	var fired time.Duration
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithDelayRule(godebouncer.DelayMin).WithAny(func(any) {
+		fired = clock.Now().Sub(start)
+	})
+
+	debouncer.SendSignalWithDelay(3*time.Second, "slow")
+	clock.Advance(time.Second)
+	debouncer.SendSignalWithDelay(5*time.Second, "slower")
+	clock.Advance(10 * time.Second)
+
+	if fired != 3*time.Second {
+		t.Errorf("Expected fired at %v, was %v", 3*time.Second, fired)
+	}
+}
+
+func TestSendSignalWithDelayNonPositive(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := virtualtime.New(start)
+	var fired time.Duration
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithAny(func(any) {
+		fired = clock.Now().Sub(start)
+	})
+
+	debouncer.SendSignalWithDelay(0, "default")
+	clock.Advance(10 * time.Second)
+
+	if fired != time.Second {
+		t.Errorf("Expected fired at %v, was %v", time.Second, fired)
+	}
+}
@@ -0,0 +1,77 @@
+package godebouncer
+
+import "time"
+
+// EventType is the type of a state transition of a debouncer.
+type EventType int
+
+const (
+	// EventArmed when a signal starts a new wait duration.
+	EventArmed EventType = iota
+	// EventExtended when a signal restarts the pending wait duration.
+	EventExtended
+	// EventCancelled when Cancel() cancels the pending wait duration.
+	EventCancelled
+	// EventFiring when the triggered function is about to be invoked.
+	EventFiring
+	// EventFired when the triggered function returned.
+	EventFired
+	// EventFailed when the triggered function attached by WithAnyResult() returned an error.
+	EventFailed
+)
+
+// String returns the name of the event type.
+func (t EventType) String() string {
+	switch t {
+	case EventArmed:
+		return "Armed"
+	case EventExtended:
+		return "Extended"
+	case EventCancelled:
+		return "Cancelled"
+	case EventFiring:
+		return "Firing"
+	case EventFired:
+		return "Fired"
+	case EventFailed:
+		return "Failed"
+	}
+	return "Unknown"
+}
+
+// Event is a state transition of a debouncer.
+type Event struct {
+	Type EventType
+	Time time.Time
+	// Err is the error returned by the triggered function for EventFailed.
+	Err error
+	// First and Last are the first and last signals covered by the pending wait duration or the trigger. For EventArmed and EventExtended, Last is the signal just accepted.
+	First Signal
+	Last  Signal
+}
+
+// eventsBufferSize is the capacity of the channel returned by Events().
+const eventsBufferSize = 128
+
+// Events returns a receive-only channel emitting the state transitions of the debouncer. The channel is never closed.
+// The events are dropped instead of blocking the debouncer when the channel is full, so the caller should receive them promptly.
+func (d *Debouncer) Events() <-chan Event {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.events == nil {
+		d.events = make(chan Event, eventsBufferSize)
+	}
+	return d.events
+}
+
+// emit sends an event about the call if Events() has been called. The caller must hold the mutex.
+func (d *Debouncer) emit(eventType EventType, c *call, err error) {
+	if d.events == nil {
+		return
+	}
+	select {
+	case d.events <- Event{Type: eventType, Time: d.scheduler.Now(), Err: err, First: c.first, Last: c.last}:
+	default:
+	}
+}
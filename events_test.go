@@ -0,0 +1,111 @@
+package godebouncer_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+func receiveEvents(events <-chan godebouncer.Event) []string {
+	var received []string
+	for {
+		select {
+		case event := <-events:
+			received = append(received, fmt.Sprintf("%s@%d", event.Type, event.Time.UnixMilli()))
+		default:
+			return received
+		}
+	}
+}
+
+func TestEvents(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock)
+	events := debouncer.Events()
+
+	debouncer.SendSignal()
+	clock.Advance(500 * time.Millisecond)
+	debouncer.SendSignal()
+	debouncer.Cancel()
+	debouncer.SendSignal()
+	clock.Advance(time.Second)
+
+	received := fmt.Sprint(receiveEvents(events))
+	expectedEvents := "[Armed@0 Extended@500 Cancelled@500 Armed@500 Firing@1500 Fired@1500]"
+	if received != expectedEvents {
+		t.Errorf("Expected events %s, was %s", expectedEvents, received)
+	}
+}
+
+func TestEventsFailed(t *testing.T) {
+	debouncer := godebouncer.New(50 * time.Millisecond).WithAnyResult(func(data any) (any, error) {
+		return nil, errors.New("sink unavailable")
+	})
+	events := debouncer.Events()
+
+	debouncer.SendSignalAndWait(context.Background(), "data")
+	<-events
+	<-events
+	event := <-events
+
+	if event.Type != godebouncer.EventFailed || event.Err == nil {
+		t.Errorf("Expected a %s event with an error, was %s with %v", godebouncer.EventFailed, event.Type, event.Err)
+	}
+}
+
+func TestEventsSignalMetadata(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithAny(func(any) {})
+	events := debouncer.Events()
+
+	debouncer.SendSignalWithData("a")
+	clock.Advance(100 * time.Millisecond)
+	debouncer.SendSignalWithData("b")
+	clock.Advance(100 * time.Millisecond)
+	debouncer.SendSignalWithData("c")
+	clock.Advance(time.Second)
+	debouncer.SendSignalWithData("d")
+
+	var covered []string
+	for _, event := range []godebouncer.Event{<-events, <-events, <-events, <-events, <-events, <-events} {
+		covered = append(covered, fmt.Sprintf("%s:%d-%d@%d", event.Type, event.First.Seq, event.Last.Seq, event.Last.ReceivedAt.UnixMilli()))
+	}
+
+	expectedCovered := "[Armed:1-1@0 Extended:1-2@100 Extended:1-3@200 Firing:1-3@200 Fired:1-3@200 Armed:4-4@1200]"
+	if fmt.Sprint(covered) != expectedCovered {
+		t.Errorf("Expected covered signals %s, was %s", expectedCovered, fmt.Sprint(covered))
+	}
+}
+
+func TestSignalMetadataCorrelatesProducerWithTrigger(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	var triggers []string
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithOnFiring(func(first, last godebouncer.Signal) {
+		triggers = append(triggers, fmt.Sprintf("%d-%d", first.Seq, last.Seq))
+	}).WithAny(func(any) {})
+
+	debouncer.SendSignalWithData("a")
+	clock.Advance(time.Second)
+	debouncer.SendSignalWithData("b")
+	signal, err := debouncer.SendSignalWithMetadata("c")
+	debouncer.SendSignalWithData("d")
+	clock.Advance(time.Second)
+
+	if err != nil || signal.Seq != 3 || !signal.ReceivedAt.Equal(time.Unix(1, 0)) {
+		t.Errorf("Expected signal 3 received at 1s, was %+v (%v)", signal, err)
+	}
+	if fmt.Sprint(triggers) != "[1-1 2-4]" {
+		t.Errorf("Expected triggers %s, was %v", "[1-1 2-4]", triggers)
+	}
+	// The producer call of signal 3 ended up in the second trigger.
+	var first, last uint64
+	fmt.Sscanf(triggers[1], "%d-%d", &first, &last)
+	if signal.Seq < first || signal.Seq > last {
+		t.Errorf("Expected signal %d to be covered by the trigger %s", signal.Seq, triggers[1])
+	}
+}
@@ -0,0 +1,32 @@
+package godebouncer
+
+import "errors"
+
+type filter struct {
+	accept         func(any) bool
+	extendOnReject bool
+}
+
+// WithFilter attached a function consulted with the payload of each SendSignalWithData() before it's accepted, and return the same instance of debouncer to use.
+// If it returns false, the payload is rejected and SendSignalWithData() returns an error: the payload never reaches the triggered function. If extendOnReject is true, a rejected payload still extends the pending wait duration, otherwise it's ignored.
+func (d *Debouncer) WithFilter(accept func(any) bool, extendOnReject bool) *Debouncer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.filter = &filter{accept: accept, extendOnReject: extendOnReject}
+	return d
+}
+
+// reject extends the pending wait duration if the filter is configured to, and returns the error of a rejected payload.
+func (d *Debouncer) reject() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.filter.extendOnReject && d.state == statePending && !d.closed {
+		c := d.pending
+		d.stopTimerFunc()
+		d.arm(c)
+		d.emit(EventExtended, c, nil)
+	}
+	return errors.New(ErrorTypeFiltered)
+}
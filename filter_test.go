@@ -0,0 +1,45 @@
+package godebouncer_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+func TestFilter(t *testing.T) {
+	testCases := []struct {
+		name           string
+		extendOnReject bool
+		expectedData   string
+	}{
+		{name: "IgnoreRejected", extendOnReject: false, expectedData: "[2]"},
+		{name: "ExtendOnReject", extendOnReject: true, expectedData: "[]"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			clock := virtualtime.New(time.Unix(0, 0))
+			triggeredData := []any{}
+			debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithFilter(func(data any) bool {
+				return data.(int)%2 == 0
+			}, testCase.extendOnReject).WithAny(func(data any) {
+				triggeredData = append(triggeredData, data)
+			})
+
+			debouncer.SendSignalWithData(2)
+			clock.Advance(500 * time.Millisecond)
+			err := debouncer.SendSignalWithData(3)
+			if err == nil || err.Error() != godebouncer.ErrorTypeFiltered {
+				t.Errorf("Expected error %q, was %v", godebouncer.ErrorTypeFiltered, err)
+			}
+			clock.Advance(500 * time.Millisecond)
+
+			if fmt.Sprint(triggeredData) != testCase.expectedData {
+				t.Errorf("Expected data %s, was %v", testCase.expectedData, triggeredData)
+			}
+		})
+	}
+}
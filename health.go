@@ -0,0 +1,16 @@
+package godebouncer
+
+// HealthChecker is implemented by anything that can report its health, e.g. *Debouncer.
+type HealthChecker interface {
+	Healthy() error
+}
+
+// AllHealthy returns the first error reported by the checkers, or nil if all of them are healthy. It's useful to surface the health of many debouncers in a single readiness probe.
+func AllHealthy(checkers ...HealthChecker) error {
+	for _, checker := range checkers {
+		if err := checker.Healthy(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package godebouncer_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+func TestHealthyWhenTriggerStuck(t *testing.T) {
+	release := make(chan struct{})
+	debouncer := godebouncer.New(50 * time.Millisecond).WithStuckThreshold(100 * time.Millisecond).WithTriggered(func() {
+		<-release
+	})
+
+	if err := debouncer.Healthy(); err != nil {
+		t.Errorf("Expected healthy before SendSignal(), got %v", err)
+	}
+
+	debouncer.SendSignal()
+	time.Sleep(200 * time.Millisecond)
+
+	err := debouncer.Healthy()
+	if err == nil || err.Error() != godebouncer.ErrorTypeTriggerStuck {
+		t.Errorf("Expected error %q, got %v", godebouncer.ErrorTypeTriggerStuck, err)
+	}
+
+	close(release)
+	<-debouncer.Done()
+
+	if err := debouncer.Healthy(); err != nil {
+		t.Errorf("Expected healthy after the triggered func finished, got %v", err)
+	}
+}
+
+func TestAllHealthy(t *testing.T) {
+	release := make(chan struct{})
+	healthy := godebouncer.New(50 * time.Millisecond)
+	stuck := godebouncer.New(50 * time.Millisecond).WithStuckThreshold(50 * time.Millisecond).WithTriggered(func() {
+		<-release
+	})
+	defer close(release)
+
+	if err := godebouncer.AllHealthy(healthy, stuck); err != nil {
+		t.Errorf("Expected all healthy, got %v", err)
+	}
+
+	stuck.SendSignal()
+	time.Sleep(200 * time.Millisecond)
+
+	if err := godebouncer.AllHealthy(healthy, stuck); err == nil {
+		t.Error("Expected an error from the stuck debouncer")
+	}
+}
+
+func TestHealthyWhenOverlappingTriggerStuck(t *testing.T) {
+	release := make(chan struct{})
+	debouncer := godebouncer.New(50 * time.Millisecond).WithStuckThreshold(100 * time.Millisecond).WithAny(func(data any) {
+		if data == "stuck" {
+			<-release
+		}
+	})
+	defer close(release)
+
+	debouncer.SendSignalWithData("stuck")
+	time.Sleep(80 * time.Millisecond)
+	debouncer.SendSignalWithData("fast")
+	debouncer.Flush()
+	time.Sleep(100 * time.Millisecond)
+
+	err := debouncer.Healthy()
+	if err == nil || err.Error() != godebouncer.ErrorTypeTriggerStuck {
+		t.Errorf("Expected error %q while the first trigger is stuck, got %v", godebouncer.ErrorTypeTriggerStuck, err)
+	}
+}
+
+func TestHealthyWhenTriggerFailing(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	failing := true
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithFailureThreshold(2).WithAnyResult(func(any) (any, error) {
+		if failing {
+			return nil, errors.New("boom")
+		}
+		return nil, nil
+	})
+
+	debouncer.SendSignalWithData("a")
+	clock.Advance(time.Second)
+	if err := debouncer.Healthy(); err != nil {
+		t.Errorf("Expected healthy after one failure, got %v", err)
+	}
+
+	debouncer.SendSignalWithData("b")
+	clock.Advance(time.Second)
+	err := debouncer.Healthy()
+	if err == nil || err.Error() != godebouncer.ErrorTypeTriggerFailing {
+		t.Errorf("Expected error %q, got %v", godebouncer.ErrorTypeTriggerFailing, err)
+	}
+
+	failing = false
+	debouncer.SendSignalWithData("c")
+	clock.Advance(time.Second)
+	if err := debouncer.Healthy(); err != nil {
+		t.Errorf("Expected healthy after a success, got %v", err)
+	}
+}
+
+func TestHealthyWhenPendingLimitReached(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithPendingLimit(3)
+
+	debouncer.SendSignal()
+	debouncer.SendSignal()
+	if err := debouncer.Healthy(); err != nil {
+		t.Errorf("Expected healthy below the limit, got %v", err)
+	}
+
+	debouncer.SendSignal()
+	err := debouncer.Healthy()
+	if err == nil || err.Error() != godebouncer.ErrorTypePendingLimit {
+		t.Errorf("Expected error %q, got %v", godebouncer.ErrorTypePendingLimit, err)
+	}
+
+	clock.Advance(time.Second)
+	if err := debouncer.Healthy(); err != nil {
+		t.Errorf("Expected healthy after the trigger, got %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package godebouncer
+
+import "time"
+
+// Hysteresis debounces a boolean state with different durations: the state must stay true for the rise duration to become active, and stay false for the fall duration to become idle.
+type Hysteresis struct {
+	state *StateDebouncer[bool]
+}
+
+// NewHysteresis creates a new instance of hysteresis, idle at first, which calls onChange when the state becomes active or idle.
+func NewHysteresis(riseDuration, fallDuration time.Duration, onChange func(active bool)) *Hysteresis {
+	state := NewStateDebouncer(false, riseDuration, func(_, active bool) {
+		onChange(active)
+	}).WithEdges(func(_, active bool) bool {
+		return active
+	}, riseDuration, fallDuration)
+	return &Hysteresis{state: state}
+}
+
+// Set reports the current raw state. A state different from the active one starts the rise or fall duration, unless it's already started; the same state as the active one cancels it.
+func (h *Hysteresis) Set(active bool) {
+	h.state.Set(active)
+}
+
+// Active returns the debounced state.
+func (h *Hysteresis) Active() bool {
+	return h.state.Value()
+}
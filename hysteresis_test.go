@@ -0,0 +1,49 @@
+package godebouncer_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+)
+
+func TestHysteresis(t *testing.T) {
+	var mu sync.Mutex
+	var changes []bool
+	hysteresis := godebouncer.NewHysteresis(100*time.Millisecond, 300*time.Millisecond, func(active bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		changes = append(changes, active)
+	})
+
+	hysteresis.Set(true)
+	time.Sleep(50 * time.Millisecond)
+	hysteresis.Set(true)
+	time.Sleep(100 * time.Millisecond)
+	if !hysteresis.Active() {
+		t.Error("Expected active after the rise duration")
+	}
+
+	hysteresis.Set(false)
+	time.Sleep(200 * time.Millisecond)
+	hysteresis.Set(true)
+	time.Sleep(200 * time.Millisecond)
+	if !hysteresis.Active() {
+		t.Error("Expected still active when the state flapped back before the fall duration")
+	}
+
+	hysteresis.Set(false)
+	time.Sleep(400 * time.Millisecond)
+	if hysteresis.Active() {
+		t.Error("Expected idle after the fall duration")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	expectedChanges := "[true false]"
+	if fmt.Sprint(changes) != expectedChanges {
+		t.Errorf("Expected changes %s, was %s", expectedChanges, fmt.Sprint(changes))
+	}
+}
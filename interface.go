@@ -0,0 +1,19 @@
+package godebouncer
+
+// Interface is the set of methods of a debouncer, so code owning a debouncer can depend on it and inject mocks or fakes in tests. *Debouncer implements Interface.
+type Interface interface {
+	// SendSignal makes an action that notifies to invoke the triggered function after a wait duration.
+	SendSignal() error
+	// SendSignalWithData makes an action that notifies to invoke the triggered function with the data after a wait duration.
+	SendSignalWithData(anyVar any) error
+	// Flush invokes the pending triggered function immediately.
+	Flush()
+	// Cancel cancels the pending triggered function and reports whether it won.
+	Cancel() bool
+	// Done returns a receive-only channel to notify the caller when the triggered func has been executed.
+	Done() <-chan struct{}
+	// Close cancels the pending signal and rejects further signals.
+	Close()
+}
+
+var _ Interface = (*Debouncer)(nil)
@@ -0,0 +1,12 @@
+// Package filewatch provides the change detection shared by the watchers polling files with os.Stat.
+package filewatch
+
+import "os"
+
+// Changed reports whether the file has been created, removed or modified between the two stats, where a nil os.FileInfo is a missing file.
+func Changed(last, info os.FileInfo) bool {
+	if last == nil || info == nil {
+		return (last == nil) != (info == nil)
+	}
+	return !last.ModTime().Equal(info.ModTime()) || last.Size() != info.Size()
+}
@@ -0,0 +1,38 @@
+package filewatch_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vnteamopen/godebouncer/internal/filewatch"
+)
+
+func TestChanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte("a"), 0o644)
+	created, _ := os.Stat(path)
+	os.WriteFile(path, []byte("ab"), 0o644)
+	modified, _ := os.Stat(path)
+
+	testCases := []struct {
+		name     string
+		last     os.FileInfo
+		info     os.FileInfo
+		expected bool
+	}{
+		{name: "Missing", last: nil, info: nil, expected: false},
+		{name: "Created", last: nil, info: created, expected: true},
+		{name: "Removed", last: created, info: nil, expected: true},
+		{name: "Unchanged", last: created, info: created, expected: false},
+		{name: "Modified", last: created, info: modified, expected: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if changed := filewatch.Changed(testCase.last, testCase.info); changed != testCase.expected {
+				t.Errorf("Expected changed %v, was %v", testCase.expected, changed)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Package virtualtime provides a deterministic virtual clock which implements godebouncer.Scheduler.
+// Time only moves when Advance() is called, and the scheduled functions run synchronously in the goroutine calling Advance(), ordered by their due time then by their scheduling order.
+package virtualtime
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is a virtual clock. The zero value is not usable, use New().
+type Clock struct {
+	mu     sync.Mutex
+	now    time.Time
+	seq    uint64
+	timers []*timer
+}
+
+type timer struct {
+	when time.Time
+	seq  uint64
+	f    func()
+}
+
+// New creates a virtual clock starting at the start time.
+func New(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+// Now returns the current virtual time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// AfterFunc schedules f to run once the virtual time reached now + duration.
+func (c *Clock) AfterFunc(duration time.Duration, f func()) func() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.seq++
+	t := &timer{when: c.now.Add(duration), seq: c.seq, f: f}
+	c.timers = append(c.timers, t)
+	sort.Slice(c.timers, func(i, j int) bool {
+		if c.timers[i].when.Equal(c.timers[j].when) {
+			return c.timers[i].seq < c.timers[j].seq
+		}
+		return c.timers[i].when.Before(c.timers[j].when)
+	})
+	return func() bool {
+		return c.remove(t)
+	}
+}
+
+// Advance moves the virtual time forward by the duration and runs every function due up to the new time.
+func (c *Clock) Advance(duration time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(duration)
+	c.mu.Unlock()
+
+	for {
+		c.mu.Lock()
+		if len(c.timers) == 0 || c.timers[0].when.After(target) {
+			c.now = target
+			c.mu.Unlock()
+			return
+		}
+		t := c.timers[0]
+		c.timers = c.timers[1:]
+		c.now = t.when
+		c.mu.Unlock()
+
+		t.f()
+	}
+}
+
+// Pending returns the number of scheduled functions which haven't run or been stopped yet.
+func (c *Clock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+func (c *Clock) remove(t *timer) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, pending := range c.timers {
+		if pending == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package virtualtime_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+func TestClockAdvanceRunsDueFunctionsInOrder(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	var calls []string
+	clock.AfterFunc(2*time.Second, func() { calls = append(calls, "b") })
+	clock.AfterFunc(time.Second, func() { calls = append(calls, "a") })
+	clock.AfterFunc(2*time.Second, func() { calls = append(calls, "c") })
+	clock.AfterFunc(3*time.Second, func() { calls = append(calls, "d") })
+	expectedCalls := "[a b c]"
+
+	clock.Advance(2 * time.Second)
+
+	if fmt.Sprint(calls) != expectedCalls {
+		t.Errorf("Expected calls %s, was %s", expectedCalls, fmt.Sprint(calls))
+	}
+	if clock.Pending() != 1 {
+		t.Errorf("Expected 1 pending function, was %d", clock.Pending())
+	}
+	if !clock.Now().Equal(time.Unix(2, 0)) {
+		t.Errorf("Expected now %v, was %v", time.Unix(2, 0), clock.Now())
+	}
+}
+
+func TestClockStop(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	called := false
+	stop := clock.AfterFunc(time.Second, func() { called = true })
+
+	if !stop() {
+		t.Error("Expected stop() to report the function was stopped")
+	}
+	if stop() {
+		t.Error("Expected stop() to report false when called twice")
+	}
+
+	clock.Advance(time.Second)
+	if called {
+		t.Error("Expected stopped function not to be called")
+	}
+}
+
+func TestClockAdvanceRunsFunctionsScheduledByCallbacks(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	count := 0
+	var tick func()
+	tick = func() {
+		count++
+		clock.AfterFunc(time.Second, tick)
+	}
+	clock.AfterFunc(time.Second, tick)
+
+	clock.Advance(3 * time.Second)
+
+	if count != 3 {
+		t.Errorf("Expected count %d, was %d", 3, count)
+	}
+}
@@ -0,0 +1,93 @@
+package godebouncer
+
+import (
+	"sync"
+	"time"
+)
+
+// Invalidator coalesces the invalidation requests of cache keys, and invokes the invalidate function once per key after no invalidation of that key has been requested for the wait duration.
+type Invalidator struct {
+	wait       time.Duration
+	invalidate func(key string)
+	maxPending int
+	mu         sync.Mutex
+	pending    map[string]*Debouncer
+	all        *Debouncer
+}
+
+// NewInvalidator creates a new instance of invalidator which calls invalidate for a key after the duration has elapsed since the last Invalidate() of that key.
+func NewInvalidator(duration time.Duration, invalidate func(key string)) *Invalidator {
+	return &Invalidator{wait: duration, invalidate: invalidate, pending: make(map[string]*Debouncer)}
+}
+
+// WithInvalidateAll collapses the pending keys into one call of invalidateAll when more than maxPending keys are pending, and return the same instance of invalidator to use.
+// While the collapse is pending, further invalidation requests of any key restart its wait duration.
+func (i *Invalidator) WithInvalidateAll(maxPending int, invalidateAll func()) *Invalidator {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.maxPending = maxPending
+	i.all = New(i.wait).WithTriggered(invalidateAll)
+	return i
+}
+
+// Invalidate requests the invalidation of the key and restarts its wait duration.
+func (i *Invalidator) Invalidate(key string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.all != nil && i.all.Stats().Pending {
+		i.all.SendSignal()
+		return
+	}
+
+	d, ok := i.pending[key]
+	if !ok {
+		if i.all != nil && len(i.pending) >= i.maxPending {
+			i.collapse()
+			return
+		}
+		d = New(i.wait)
+		d.WithTriggered(func() {
+			i.fire(key, d)
+		})
+		i.pending[key] = d
+	}
+	d.SendSignal()
+}
+
+// Flush invokes the pending invalidations immediately.
+func (i *Invalidator) Flush() {
+	i.mu.Lock()
+	debouncers := make([]*Debouncer, 0, len(i.pending)+1)
+	for _, d := range i.pending {
+		debouncers = append(debouncers, d)
+	}
+	if i.all != nil {
+		debouncers = append(debouncers, i.all)
+	}
+	i.mu.Unlock()
+
+	for _, d := range debouncers {
+		d.Flush()
+	}
+}
+
+// collapse replaces the pending keys with one pending invalidation of all keys. The caller must hold the mutex.
+func (i *Invalidator) collapse() {
+	for key, d := range i.pending {
+		d.Cancel()
+		delete(i.pending, key)
+	}
+	i.all.SendSignal()
+}
+
+func (i *Invalidator) fire(key string, d *Debouncer) {
+	i.mu.Lock()
+	if i.pending[key] == d {
+		delete(i.pending, key)
+	}
+	i.mu.Unlock()
+
+	i.invalidate(key)
+}
@@ -0,0 +1,54 @@
+package godebouncer_test
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+)
+
+func TestInvalidatorCoalescesPerKey(t *testing.T) {
+	var mu sync.Mutex
+	var invalidated []string
+	invalidator := godebouncer.NewInvalidator(100*time.Millisecond, func(key string) {
+		mu.Lock()
+		defer mu.Unlock()
+		invalidated = append(invalidated, key)
+	})
+
+	for i := 0; i < 3; i++ {
+		invalidator.Invalidate("user:1")
+		invalidator.Invalidate("user:2")
+	}
+	time.Sleep(300 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	sort.Strings(invalidated)
+	expectedInvalidated := "[user:1 user:2]"
+	if fmt.Sprint(invalidated) != expectedInvalidated {
+		t.Errorf("Expected invalidated %s, was %s", expectedInvalidated, fmt.Sprint(invalidated))
+	}
+}
+
+func TestInvalidatorCollapsesToInvalidateAll(t *testing.T) {
+	invalidated, invalidatedAll := 0, 0
+	invalidator := godebouncer.NewInvalidator(time.Hour, func(key string) {
+		invalidated++
+	}).WithInvalidateAll(2, func() {
+		invalidatedAll++
+	})
+
+	invalidator.Invalidate("user:1")
+	invalidator.Invalidate("user:2")
+	invalidator.Invalidate("user:3")
+	invalidator.Invalidate("user:4")
+	invalidator.Flush()
+
+	if invalidated != 0 || invalidatedAll != 1 {
+		t.Errorf("Expected 0 invalidated keys and 1 invalidate all, was %d and %d", invalidated, invalidatedAll)
+	}
+}
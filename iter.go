@@ -0,0 +1,62 @@
+package godebouncer
+
+import (
+	"iter"
+	"time"
+)
+
+// Coalesce returns an iterator which yields the values of seq in batches. A batch is yielded once seq hasn't produced a value for the wait duration, or when seq ends.
+// seq runs in its own goroutine; if the caller stops the iteration early, seq is stopped at its next value.
+func Coalesce[T any](wait time.Duration, seq iter.Seq[T]) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		values := make(chan T)
+		stop := make(chan struct{})
+		defer close(stop)
+
+		go func() {
+			defer close(values)
+			for v := range seq {
+				select {
+				case values <- v:
+				case <-stop:
+					return
+				}
+			}
+		}()
+
+		timer := time.NewTimer(wait)
+		timer.Stop()
+		defer timer.Stop()
+
+		var batch []T
+		for {
+			select {
+			case v, ok := <-values:
+				if !ok {
+					if len(batch) > 0 {
+						yield(batch)
+					}
+					return
+				}
+				batch = append(batch, v)
+				timer.Reset(wait)
+			case <-timer.C:
+				if !yield(batch) {
+					return
+				}
+				batch = nil
+			}
+		}
+	}
+}
+
+// CoalesceSignals is the signal-only variant of Coalesce. It yields the number of values seq produced before each quiet period of the wait duration.
+func CoalesceSignals[T any](wait time.Duration, seq iter.Seq[T]) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for batch := range Coalesce(wait, seq) {
+			if !yield(len(batch)) {
+				return
+			}
+		}
+	}
+}
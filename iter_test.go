@@ -0,0 +1,61 @@
+package godebouncer_test
+
+import (
+	"fmt"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+)
+
+func burst(sizes ...int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		value := 0
+		for _, size := range sizes {
+			for i := 0; i < size; i++ {
+				value++
+				if !yield(value) {
+					return
+				}
+			}
+			time.Sleep(200 * time.Millisecond)
+		}
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	var batches [][]int
+	for batch := range godebouncer.Coalesce(100*time.Millisecond, burst(3, 2)) {
+		batches = append(batches, batch)
+	}
+	expectedBatches := "[[1 2 3] [4 5]]"
+
+	if fmt.Sprint(batches) != expectedBatches {
+		t.Errorf("Expected batches %s, was %s", expectedBatches, fmt.Sprint(batches))
+	}
+}
+
+func TestCoalesceBreak(t *testing.T) {
+	count := 0
+	for range godebouncer.Coalesce(100*time.Millisecond, burst(3, 2, 1)) {
+		count++
+		break
+	}
+
+	if count != 1 {
+		t.Errorf("Expected count %d, was %d", 1, count)
+	}
+}
+
+func TestCoalesceSignals(t *testing.T) {
+	var counts []int
+	for count := range godebouncer.CoalesceSignals(100*time.Millisecond, burst(4, 1)) {
+		counts = append(counts, count)
+	}
+	expectedCounts := "[4 1]"
+
+	if fmt.Sprint(counts) != expectedCounts {
+		t.Errorf("Expected counts %s, was %s", expectedCounts, fmt.Sprint(counts))
+	}
+}
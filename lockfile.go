@@ -0,0 +1,99 @@
+package godebouncer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// lockFile coordinates the debouncers of many processes on the same host through a file holding the time of the last signal and the time of the last trigger of all the processes.
+type lockFile struct {
+	path string
+}
+
+// lockFileDecision is what a debouncer does when its wait duration elapsed.
+type lockFileDecision int
+
+const (
+	// lockFileFire when the quiet period elapsed for all the processes, and no other process triggered since the last signal.
+	lockFileFire lockFileDecision = iota
+	// lockFileDefer when another process sent a signal within the wait duration.
+	lockFileDefer
+	// lockFileSkip when another process already triggered for the last signal.
+	lockFileSkip
+)
+
+// WithLockFile makes the debouncers of many processes on the same host, e.g. parallel CLI invocations, debounce a shared action through the file at path, and return the same instance of debouncer to use.
+// Each signal is recorded in the file, and only one process invokes the triggered function per quiet period of all the processes. Flush() invokes it immediately and records the trigger, so the other processes drop their pending signals. The file is locked with flock(2) where it's available.
+// The coordination fails open: if the file can't be read or written, the signals are still accepted and the triggered function is invoked when the wait duration elapsed, as without WithLockFile().
+func (d *Debouncer) WithLockFile(path string) *Debouncer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.lockFile = &lockFile{path: path}
+	return d
+}
+
+// signal records the time of a signal.
+func (l *lockFile) signal(now time.Time) error {
+	return l.update(func(lastSignal, lastFired int64) (int64, int64) {
+		return max(lastSignal, now.UnixNano()), lastFired
+	})
+}
+
+// flush records a trigger invoked by Flush().
+func (l *lockFile) flush(now time.Time) error {
+	return l.update(func(lastSignal, lastFired int64) (int64, int64) {
+		return lastSignal, max(lastFired, now.UnixNano())
+	})
+}
+
+// expire decides whether this process invokes the triggered function, and records the trigger if it does. When the trigger is deferred, it also returns the time left until the quiet period of the last signal of all the processes elapses.
+func (l *lockFile) expire(now time.Time, duration time.Duration) (lockFileDecision, time.Duration) {
+	decision, remaining := lockFileFire, time.Duration(0)
+	err := l.update(func(lastSignal, lastFired int64) (int64, int64) {
+		switch {
+		case lastSignal != 0 && lastFired >= lastSignal:
+			decision = lockFileSkip
+		case now.UnixNano() < lastSignal+int64(duration):
+			decision = lockFileDefer
+			remaining = time.Duration(lastSignal + int64(duration) - now.UnixNano())
+		default:
+			lastFired = now.UnixNano()
+		}
+		return lastSignal, lastFired
+	})
+	if err != nil {
+		return lockFileFire, 0
+	}
+	return decision, remaining
+}
+
+// update replaces the times in the file with the result of f, while holding the lock of the file.
+func (l *lockFile) update(f func(lastSignal, lastFired int64) (int64, int64)) error {
+	file, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := lock(file); err != nil {
+		return err
+	}
+	defer unlock(file)
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+	var lastSignal, lastFired int64
+	fmt.Sscan(string(content), &lastSignal, &lastFired)
+
+	lastSignal, lastFired = f(lastSignal, lastFired)
+	if err := file.Truncate(0); err != nil {
+		return err
+	}
+	_, err = file.WriteAt([]byte(fmt.Sprintf("%d %d\n", lastSignal, lastFired)), 0)
+	return err
+}
@@ -0,0 +1,14 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package godebouncer
+
+import "os"
+
+// lock is a no-op where flock(2) isn't available: the coordination through the file is best effort.
+func lock(file *os.File) error {
+	return nil
+}
+
+func unlock(file *os.File) error {
+	return nil
+}
@@ -0,0 +1,130 @@
+package godebouncer_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+func TestLockFileFiresOncePerQuietPeriod(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debouncer.lock")
+	clock := virtualtime.New(time.Now())
+	countPtr, incrementCount := createIncrementCount(0)
+	first := godebouncer.New(time.Second).WithScheduler(clock).WithLockFile(path).WithTriggered(incrementCount)
+	second := godebouncer.New(time.Second).WithScheduler(clock).WithLockFile(path).WithTriggered(incrementCount)
+
+	first.SendSignal()
+	clock.Advance(500 * time.Millisecond)
+	second.SendSignal()
+
+	clock.Advance(500 * time.Millisecond)
+	if *countPtr != 0 {
+		t.Errorf("Expected count %d, was %d", 0, *countPtr)
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if *countPtr != 1 {
+		t.Errorf("Expected count %d, was %d", 1, *countPtr)
+	}
+
+	clock.Advance(time.Second)
+	if *countPtr != 1 {
+		t.Errorf("Expected count %d, was %d", 1, *countPtr)
+	}
+	if first.Stats().Pending || second.Stats().Pending {
+		t.Error("Expected no pending signal once a process triggered")
+	}
+
+	first.SendSignal()
+	clock.Advance(time.Second)
+	if *countPtr != 2 {
+		t.Errorf("Expected count %d, was %d", 2, *countPtr)
+	}
+}
+
+func TestLockFileDeferUntilPeerQuietPeriod(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debouncer.lock")
+	clock := virtualtime.New(time.Now())
+	countPtr, incrementCount := createIncrementCount(0)
+	first := godebouncer.New(time.Second).WithScheduler(clock).WithLockFile(path).WithTriggered(incrementCount)
+	second := godebouncer.New(time.Second).WithScheduler(clock).WithLockFile(path)
+
+	first.SendSignal()
+	clock.Advance(500 * time.Millisecond)
+	second.SendSignal()
+	second.Cancel()
+
+	clock.Advance(999 * time.Millisecond)
+	if *countPtr != 0 {
+		t.Errorf("Expected count %d, was %d", 0, *countPtr)
+	}
+
+	clock.Advance(time.Millisecond)
+	if *countPtr != 1 {
+		t.Errorf("Expected count %d, was %d", 1, *countPtr)
+	}
+}
+
+func TestLockFileFlush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debouncer.lock")
+	clock := virtualtime.New(time.Now())
+	countPtr, incrementCount := createIncrementCount(0)
+	first := godebouncer.New(time.Second).WithScheduler(clock).WithLockFile(path).WithTriggered(incrementCount)
+	second := godebouncer.New(time.Second).WithScheduler(clock).WithLockFile(path).WithTriggered(incrementCount)
+
+	first.SendSignal()
+	second.SendSignal()
+	clock.Advance(500 * time.Millisecond)
+	first.Flush()
+	if *countPtr != 1 {
+		t.Errorf("Expected count %d, was %d", 1, *countPtr)
+	}
+
+	clock.Advance(time.Second)
+	if *countPtr != 1 {
+		t.Errorf("Expected count %d, was %d", 1, *countPtr)
+	}
+	if second.Stats().Pending {
+		t.Error("Expected the peer to drop its pending signal after Flush()")
+	}
+}
+
+func TestLockFileClosedDebouncer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debouncer.lock")
+	debouncer := godebouncer.New(time.Second).WithLockFile(path)
+	debouncer.Close()
+
+	if err := debouncer.SendSignal(); err == nil {
+		t.Error("Expected an error after Close()")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected no lock file after a rejected signal, got %v", err)
+	}
+}
+
+func TestLockFileDeferWithSignalDelay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debouncer.lock")
+	clock := virtualtime.New(time.Now())
+	countPtr, incrementCount := createIncrementCountAny(0)
+	first := godebouncer.New(time.Second).WithScheduler(clock).WithLockFile(path).WithAny(incrementCount)
+	second := godebouncer.New(time.Second).WithScheduler(clock).WithLockFile(path)
+
+	first.SendSignalWithDelay(500*time.Millisecond, "urgent")
+	clock.Advance(200 * time.Millisecond)
+	second.SendSignal()
+	second.Cancel()
+
+	clock.Advance(499 * time.Millisecond)
+	if *countPtr != 0 {
+		t.Errorf("Expected count %d, was %d", 0, *countPtr)
+	}
+
+	clock.Advance(time.Millisecond)
+	if *countPtr != 1 {
+		t.Errorf("Expected count %d, was %d", 1, *countPtr)
+	}
+}
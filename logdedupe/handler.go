@@ -0,0 +1,129 @@
+// Package logdedupe provides a slog.Handler which suppresses identical log records repeated within a window.
+// The first record is passed through immediately, the repetitions are counted, and once no identical record has been logged for the window, the last one is emitted with a "(repeated N times)" suffix.
+package logdedupe
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+)
+
+// Handler is a slog.Handler coalescing identical records, i.e. records with the same level, message and attributes, including the ones added by WithAttrs() and WithGroup().
+type Handler struct {
+	next   slog.Handler
+	window time.Duration
+	state  *state
+	// prefix identifies the attributes and groups added by WithAttrs() and WithGroup(), it's the start of the keys of the records.
+	prefix string
+}
+
+// state is shared by the handler and the handlers derived by WithAttrs() and WithGroup().
+
+type state struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+type entry struct {
+	next      slog.Handler
+	debouncer *godebouncer.Debouncer
+	record    slog.Record
+	repeated  int
+}
+
+// New creates a handler passing the records to next, and suppressing identical records repeated within the window.
+func New(next slog.Handler, window time.Duration) *Handler {
+	return &Handler{next: next, window: window, state: &state{entries: make(map[string]*entry)}}
+}
+
+// Enabled reports whether the next handler handles records at the level.
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle passes the record to the next handler if no identical record has been logged within the window, otherwise the record is counted as a repetition.
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	key := h.prefix + recordKey(record)
+
+	h.state.mu.Lock()
+	if e, ok := h.state.entries[key]; ok {
+		e.repeated++
+		e.record = record.Clone()
+		e.debouncer.SendSignal()
+		h.state.mu.Unlock()
+		return nil
+	}
+	e := &entry{next: h.next, record: record.Clone()}
+	e.debouncer = godebouncer.New(h.window).WithTriggered(func() {
+		h.flush(key, e)
+	})
+	h.state.entries[key] = e
+	e.debouncer.SendSignal()
+	h.state.mu.Unlock()
+
+	return h.next.Handle(ctx, record)
+}
+
+// WithAttrs returns a handler whose records include the attributes. It shares the repetitions with h, so the records are coalesced with the ones of the handlers with the same attributes, and Flush() on h emits them.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var prefix strings.Builder
+	prefix.WriteString(h.prefix)
+	for _, attr := range attrs {
+		fmt.Fprintf(&prefix, "%s|", attr)
+	}
+	return &Handler{next: h.next.WithAttrs(attrs), window: h.window, state: h.state, prefix: prefix.String()}
+}
+
+// WithGroup returns a handler qualifying the attributes with the group name. It shares the repetitions with h, like WithAttrs().
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{next: h.next.WithGroup(name), window: h.window, state: h.state, prefix: h.prefix + name + "{|"}
+}
+
+// Flush emits the pending repetitions immediately, including the ones of the derived handlers, e.g. before the process exits.
+func (h *Handler) Flush() {
+	h.state.mu.Lock()
+	entries := make([]*entry, 0, len(h.state.entries))
+	for _, e := range h.state.entries {
+		entries = append(entries, e)
+	}
+	h.state.mu.Unlock()
+
+	for _, e := range entries {
+		e.debouncer.Flush()
+	}
+}
+
+func (h *Handler) flush(key string, e *entry) {
+	h.state.mu.Lock()
+	if h.state.entries[key] == e {
+		delete(h.state.entries, key)
+	}
+	repeated, record := e.repeated, e.record
+	e.repeated = 0
+	h.state.mu.Unlock()
+
+	if repeated == 0 {
+		return
+	}
+	summary := slog.NewRecord(record.Time, record.Level, fmt.Sprintf("%s (repeated %d times)", record.Message, repeated), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		summary.AddAttrs(attr)
+		return true
+	})
+	e.next.Handle(context.Background(), summary)
+}
+
+func recordKey(record slog.Record) string {
+	var key strings.Builder
+	fmt.Fprintf(&key, "%s|%s", record.Level, record.Message)
+	record.Attrs(func(attr slog.Attr) bool {
+		fmt.Fprintf(&key, "|%s", attr)
+		return true
+	})
+	return key.String()
+}
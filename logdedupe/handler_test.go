@@ -0,0 +1,104 @@
+package logdedupe_test
+
+import (
+	"bytes"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer/logdedupe"
+)
+
+// syncBuffer is a bytes.Buffer which can be written by the timers of the handler while the test reads it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func newLogger(buf *syncBuffer, window time.Duration) (*slog.Logger, *logdedupe.Handler) {
+	handler := logdedupe.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	}), window)
+	return slog.New(handler), handler
+}
+
+func TestHandlerCoalescesRepeatedRecords(t *testing.T) {
+	var buf syncBuffer
+	logger, _ := newLogger(&buf, 100*time.Millisecond)
+
+	for i := 0; i < 5; i++ {
+		logger.Error("connection refused", "host", "db")
+	}
+	logger.Error("connection refused", "host", "cache")
+	time.Sleep(300 * time.Millisecond)
+
+	expectedOutput := `level=ERROR msg="connection refused" host=db
+level=ERROR msg="connection refused" host=cache
+level=ERROR msg="connection refused (repeated 4 times)" host=db
+`
+	if buf.String() != expectedOutput {
+		t.Errorf("Expected output:\n%s\nwas:\n%s", expectedOutput, buf.String())
+	}
+}
+
+func TestHandlerFlush(t *testing.T) {
+	var buf syncBuffer
+	logger, handler := newLogger(&buf, time.Hour)
+
+	logger.Info("retrying")
+	logger.Info("retrying")
+	handler.Flush()
+	logger.Info("retrying")
+
+	expectedOutput := `level=INFO msg=retrying
+level=INFO msg="retrying (repeated 1 times)"
+level=INFO msg=retrying
+`
+	if buf.String() != expectedOutput {
+		t.Errorf("Expected output:\n%s\nwas:\n%s", expectedOutput, buf.String())
+	}
+}
+
+func TestHandlerFlushDerivedHandlers(t *testing.T) {
+	var buf syncBuffer
+	logger, handler := newLogger(&buf, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		logger.With("host", "db").Warn("slow query")
+	}
+	logger.WithGroup("request").With("id", 1).Warn("slow query")
+	logger.WithGroup("request").With("id", 1).Warn("slow query")
+	handler.Flush()
+
+	expectedOutput := `level=WARN msg="slow query" host=db
+level=WARN msg="slow query" request.id=1
+level=WARN msg="slow query (repeated 1 times)" request.id=1
+level=WARN msg="slow query (repeated 2 times)" host=db
+`
+	// The repetitions are flushed in no particular order.
+	lines := strings.SplitAfter(buf.String(), "\n")
+	slices.Sort(lines[2:])
+	if strings.Join(lines, "") != expectedOutput {
+		t.Errorf("Expected output:\n%s\nwas:\n%s", expectedOutput, buf.String())
+	}
+}
@@ -0,0 +1,111 @@
+package godebouncer
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// MetricKind tells how the updates of a metric are aggregated.
+type MetricKind int
+
+const (
+	// MetricCounter sums the increments of the metric.
+	MetricCounter MetricKind = iota
+	// MetricGauge keeps the last value of the metric.
+	MetricGauge
+)
+
+// MetricUpdate is the aggregated update of a metric emitted once per window.
+type MetricUpdate struct {
+	Name string
+	Kind MetricKind
+	// Value is the sum of the increments of a counter, or the last value of a gauge.
+	Value float64
+	// Samples is the number of updates aggregated.
+	Samples int
+}
+
+type metricKey struct {
+	name string
+	kind MetricKind
+}
+
+// MetricsAggregator accepts high-frequency updates of counters and gauges from hot paths, aggregates them per metric within a window, and emits one aggregated update per metric to the sink when the window ends.
+// The window starts with the first update since the last emit and isn't extended by further updates, so a steady stream of updates is still emitted once per window.
+type MetricsAggregator struct {
+	mu      sync.Mutex
+	sink    func(updates []MetricUpdate)
+	window  *Debouncer
+	updates map[metricKey]*MetricUpdate
+}
+
+// NewMetricsAggregator creates a new instance of metrics aggregator emitting the aggregated updates to sink at the end of each window, sorted by name.
+func NewMetricsAggregator(window time.Duration, sink func(updates []MetricUpdate)) *MetricsAggregator {
+	m := &MetricsAggregator{sink: sink, updates: make(map[metricKey]*MetricUpdate)}
+	m.window = New(window).WithTriggered(m.emit)
+	return m
+}
+
+// WithScheduler replaces the scheduler used to wait for the window, and return the same instance of metrics aggregator to use.
+func (m *MetricsAggregator) WithScheduler(scheduler Scheduler) *MetricsAggregator {
+	m.window.WithScheduler(scheduler)
+	return m
+}
+
+// Add increments the counter by delta.
+func (m *MetricsAggregator) Add(name string, delta float64) {
+	m.update(metricKey{name: name, kind: MetricCounter}, func(u *MetricUpdate) {
+		u.Value += delta
+	})
+}
+
+// Set sets the gauge to value.
+func (m *MetricsAggregator) Set(name string, value float64) {
+	m.update(metricKey{name: name, kind: MetricGauge}, func(u *MetricUpdate) {
+		u.Value = value
+	})
+}
+
+// Flush emits the aggregated updates immediately.
+func (m *MetricsAggregator) Flush() {
+	m.window.Flush()
+}
+
+func (m *MetricsAggregator) update(key metricKey, aggregate func(u *MetricUpdate)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.updates[key]
+	if !ok {
+		u = &MetricUpdate{Name: key.name, Kind: key.kind}
+		m.updates[key] = u
+	}
+	aggregate(u)
+	u.Samples++
+
+	if len(m.updates) == 1 && u.Samples == 1 {
+		m.window.SendSignal()
+	}
+}
+
+func (m *MetricsAggregator) emit() {
+	m.mu.Lock()
+	updates := make([]MetricUpdate, 0, len(m.updates))
+	for _, u := range m.updates {
+		updates = append(updates, *u)
+	}
+	m.updates = make(map[metricKey]*MetricUpdate)
+	m.mu.Unlock()
+
+	if len(updates) == 0 {
+		return
+	}
+	sort.Slice(updates, func(i, j int) bool {
+		if updates[i].Name != updates[j].Name {
+			return updates[i].Name < updates[j].Name
+		}
+		return updates[i].Kind < updates[j].Kind
+	})
+	m.sink(updates)
+}
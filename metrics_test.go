@@ -0,0 +1,31 @@
+package godebouncer_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+func TestMetricsAggregator(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	var emitted []string
+	aggregator := godebouncer.NewMetricsAggregator(time.Second, func(updates []godebouncer.MetricUpdate) {
+		emitted = append(emitted, fmt.Sprint(updates))
+	}).WithScheduler(clock)
+
+	// The updates keep coming, but the window isn't extended.
+	for i := 0; i < 15; i++ {
+		aggregator.Add("requests", 1)
+		aggregator.Set("queue", float64(i))
+		clock.Advance(100 * time.Millisecond)
+	}
+	aggregator.Flush()
+
+	expectedEmitted := "[[{queue 1 9 10} {requests 0 10 10}] [{queue 1 14 5} {requests 0 5 5}]]"
+	if fmt.Sprint(emitted) != expectedEmitted {
+		t.Errorf("Expected emitted updates %s, was %v", expectedEmitted, emitted)
+	}
+}
@@ -0,0 +1,113 @@
+package godebouncer
+
+import (
+	"sync"
+	"time"
+)
+
+// Notifier consolidates the events notified to a recipient, and delivers them in one message once no event has been notified to that recipient for the wait duration.
+type Notifier struct {
+	wait     time.Duration
+	deliver  func(recipient string, message any) error
+	render   func(recipient string, events []any) any
+	attempts int
+	backoff  time.Duration
+	onError  func(recipient string, err error)
+	mu       sync.Mutex
+	pending  map[string]*pendingNotification
+}
+
+type pendingNotification struct {
+	debouncer *Debouncer
+	events    []any
+}
+
+// NewNotifier creates a new instance of notifier which calls deliver once per recipient after the duration has elapsed since the last Notify() to that recipient.
+// By default, the delivered message is the []any of the consolidated events.
+func NewNotifier(duration time.Duration, deliver func(recipient string, message any) error) *Notifier {
+	return &Notifier{
+		wait:     duration,
+		deliver:  deliver,
+		render:   func(_ string, events []any) any { return events },
+		attempts: 1,
+		pending:  make(map[string]*pendingNotification),
+	}
+}
+
+// WithTemplate attached a function rendering the consolidated events of a recipient into the delivered message, and return the same instance of notifier to use.
+func (n *Notifier) WithTemplate(render func(recipient string, events []any) any) *Notifier {
+	n.render = render
+	return n
+}
+
+// WithRetry makes the notifier try to deliver a message up to attempts times, waiting the backoff between attempts, and return the same instance of notifier to use.
+func (n *Notifier) WithRetry(attempts int, backoff time.Duration) *Notifier {
+	n.attempts = max(attempts, 1)
+	n.backoff = backoff
+	return n
+}
+
+// WithOnError attached a function called when a message couldn't be delivered after all attempts, and return the same instance of notifier to use.
+func (n *Notifier) WithOnError(onError func(recipient string, err error)) *Notifier {
+	n.onError = onError
+	return n
+}
+
+// Notify adds an event to the pending notification of the recipient and restarts its wait duration.
+func (n *Notifier) Notify(recipient string, event any) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	p, ok := n.pending[recipient]
+	if !ok {
+		p = &pendingNotification{}
+		p.debouncer = New(n.wait).WithTriggered(func() {
+			n.send(recipient, p)
+		})
+		n.pending[recipient] = p
+	}
+	p.events = append(p.events, event)
+	p.debouncer.SendSignal()
+}
+
+// Flush delivers the pending notifications of all recipients immediately, e.g. before the process exits.
+func (n *Notifier) Flush() {
+	n.mu.Lock()
+	pending := make([]*pendingNotification, 0, len(n.pending))
+	for _, p := range n.pending {
+		pending = append(pending, p)
+	}
+	n.mu.Unlock()
+
+	for _, p := range pending {
+		p.debouncer.Flush()
+	}
+}
+
+func (n *Notifier) send(recipient string, p *pendingNotification) {
+	n.mu.Lock()
+	if n.pending[recipient] == p {
+		delete(n.pending, recipient)
+	}
+	events := p.events
+	p.events = nil
+	n.mu.Unlock()
+
+	if len(events) == 0 {
+		return
+	}
+	message := n.render(recipient, events)
+
+	var err error
+	for attempt := 0; attempt < n.attempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(n.backoff)
+		}
+		if err = n.deliver(recipient, message); err == nil {
+			return
+		}
+	}
+	if n.onError != nil {
+		n.onError(recipient, err)
+	}
+}
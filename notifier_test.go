@@ -0,0 +1,58 @@
+package godebouncer_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+)
+
+func TestNotifierConsolidatesPerRecipient(t *testing.T) {
+	var mu sync.Mutex
+	delivered := map[string]any{}
+	notifier := godebouncer.NewNotifier(100*time.Millisecond, func(recipient string, message any) error {
+		mu.Lock()
+		defer mu.Unlock()
+		delivered[recipient] = message
+		return nil
+	}).WithTemplate(func(recipient string, events []any) any {
+		return fmt.Sprintf("%s: %d events %v", recipient, len(events), events)
+	})
+
+	notifier.Notify("alice", "build failed")
+	notifier.Notify("bob", "build failed")
+	notifier.Notify("alice", "build fixed")
+	time.Sleep(300 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	expectedDelivered := "map[alice:alice: 2 events [build failed build fixed] bob:bob: 1 events [build failed]]"
+	if fmt.Sprint(delivered) != expectedDelivered {
+		t.Errorf("Expected delivered %s, was %s", expectedDelivered, fmt.Sprint(delivered))
+	}
+}
+
+func TestNotifierRetry(t *testing.T) {
+	attempts := 0
+	var deliveryErr error
+	notifier := godebouncer.NewNotifier(time.Hour, func(recipient string, message any) error {
+		attempts++
+		return errors.New("smtp unavailable")
+	}).WithRetry(3, 10*time.Millisecond).WithOnError(func(recipient string, err error) {
+		deliveryErr = err
+	})
+	expectedAttempts := 3
+
+	notifier.Notify("alice", "build failed")
+	notifier.Flush()
+
+	if attempts != expectedAttempts {
+		t.Errorf("Expected attempts %d, was %d", expectedAttempts, attempts)
+	}
+	if deliveryErr == nil {
+		t.Error("Expected OnError to be called")
+	}
+}
@@ -0,0 +1,64 @@
+package godebouncer
+
+// PayloadPolicy tells which payload the triggered function receives when many signals with data are coalesced.
+type PayloadPolicy int
+
+const (
+	// PayloadLastWins passes the payload of the last signal, it's the default.
+	PayloadLastWins PayloadPolicy = iota
+	// PayloadFirstWins passes the payload of the first signal, e.g. to act on the event which started the burst.
+	PayloadFirstWins
+)
+
+// WithPayloadPolicy sets which payload the triggered function receives when many signals are coalesced, and return the same instance of debouncer to use.
+func (d *Debouncer) WithPayloadPolicy(policy PayloadPolicy) *Debouncer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.payloadPolicy = policy
+	return d
+}
+
+// WithReducer makes the triggered function receive the payloads of the coalesced signals combined by reduce, instead of the payload picked by the payload policy, and return the same instance of debouncer to use.
+// reduce is called on each signal extending the pending wait, with the pending payload and the payload of the signal. It's called with the mutex held, so it mustn't call the debouncer.
+func (d *Debouncer) WithReducer(reduce func(pending, data any) any) *Debouncer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.reduce = reduce
+	return d
+}
+
+// retainPayload replaces the payload of the call by the payload retained from the pending call. The caller must hold the mutex.
+func (d *Debouncer) retainPayload(c *call, pending *call) {
+	if !d.isAny {
+		return
+	}
+	switch {
+	case d.reduce != nil:
+		c.data = d.reduce(pending.data, c.data)
+		c.invoke = d.callAny(c.data)
+	case d.payloadPolicy == PayloadFirstWins:
+		c.data, c.invoke = pending.data, pending.invoke
+	}
+}
+
+// retainFailedPayload folds the payload of the failed call into the pending call, as if it was signaled first. It returns false if the payload policy keeps the payload of the pending call only. The caller must hold the mutex.
+func (d *Debouncer) retainFailedPayload(failed *call) bool {
+	pending := d.pending
+	switch {
+	case !d.isAny:
+		return false
+	case d.reduce != nil:
+		pending.data = d.reduce(failed.data, pending.data)
+		pending.invoke = d.callAny(pending.data)
+	case d.payloadPolicy == PayloadFirstWins:
+		pending.data, pending.invoke = failed.data, failed.invoke
+	default:
+		return false
+	}
+	pending.first = failed.first
+	pending.count += failed.count
+	pending.bytes += failed.bytes
+	return true
+}
@@ -0,0 +1,86 @@
+package godebouncer_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+func TestPayloadPolicy(t *testing.T) {
+	testCases := []struct {
+		name         string
+		policy       godebouncer.PayloadPolicy
+		expectedData string
+	}{
+		{name: "LastWins", policy: godebouncer.PayloadLastWins, expectedData: "[c f]"},
+		{name: "FirstWins", policy: godebouncer.PayloadFirstWins, expectedData: "[a d]"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			clock := virtualtime.New(time.Unix(0, 0))
+			var triggeredData []any
+			debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithPayloadPolicy(testCase.policy).WithAny(func(data any) {
+				triggeredData = append(triggeredData, data)
+			})
+
+			for _, data := range []string{"a", "b", "c"} {
+				debouncer.SendSignalWithData(data)
+			}
+			clock.Advance(time.Second)
+			for _, data := range []string{"d", "e", "f"} {
+				debouncer.SendSignalWithData(data)
+			}
+			clock.Advance(time.Second)
+
+			if fmt.Sprint(triggeredData) != testCase.expectedData {
+				t.Errorf("Expected data %s, was %v", testCase.expectedData, triggeredData)
+			}
+		})
+	}
+}
+
+func TestReducer(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	var triggeredData any
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithReducer(func(pending, data any) any {
+		return pending.(int) + data.(int)
+	}).WithAny(func(data any) {
+		triggeredData = data
+	})
+
+	for i := 1; i <= 4; i++ {
+		debouncer.SendSignalWithData(i)
+	}
+	clock.Advance(time.Second)
+
+	if triggeredData != 10 {
+		t.Errorf("Expected data %d, was %v", 10, triggeredData)
+	}
+}
+
+func TestTransformBeforeReducer(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	var triggeredData any
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithFilter(func(data any) bool {
+		return data.(string) != ""
+	}, false).WithTransform(func(data any) any {
+		return len(data.(string))
+	}).WithReducer(func(pending, data any) any {
+		return pending.(int) + data.(int)
+	}).WithAny(func(data any) {
+		triggeredData = data
+	})
+
+	for _, data := range []string{"ab", "", "cde"} {
+		debouncer.SendSignalWithData(data)
+	}
+	clock.Advance(time.Second)
+
+	if triggeredData != 5 {
+		t.Errorf("Expected data %d, was %v", 5, triggeredData)
+	}
+}
@@ -0,0 +1,35 @@
+package godebouncer
+
+import (
+	"time"
+
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+// PollScheduler is a scheduler without background goroutines or runtime timers, for single-threaded event loops: the owner calls Poll() from its main loop, and the due triggered functions run synchronously in Poll().
+// The time only moves when Poll() is called, so the wait durations are measured from the last call of Poll() before the signals.
+type PollScheduler struct {
+	clock *virtualtime.Clock
+}
+
+// NewPollScheduler creates a new instance of poll scheduler starting at the start time, usually time.Now().
+func NewPollScheduler(start time.Time) *PollScheduler {
+	return &PollScheduler{clock: virtualtime.New(start)}
+}
+
+// Now returns the time of the last call of Poll(), or the start time.
+func (p *PollScheduler) Now() time.Time {
+	return p.clock.Now()
+}
+
+// AfterFunc schedules f to run in the first call of Poll() at or after the duration from Now().
+func (p *PollScheduler) AfterFunc(duration time.Duration, f func()) func() bool {
+	return p.clock.AfterFunc(duration, f)
+}
+
+// Poll moves the time to now and runs the functions due up to now, in the order of their due time. It does nothing if now is before the time of the last call.
+func (p *PollScheduler) Poll(now time.Time) {
+	if elapsed := now.Sub(p.clock.Now()); elapsed > 0 {
+		p.clock.Advance(elapsed)
+	}
+}
@@ -0,0 +1,29 @@
+package godebouncer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+)
+
+func TestPollScheduler(t *testing.T) {
+	start := time.Unix(0, 0)
+	scheduler := godebouncer.NewPollScheduler(start)
+	countPtr, incrementCount := createIncrementCount(0)
+	debouncer := godebouncer.New(time.Second).WithScheduler(scheduler).WithTriggered(incrementCount)
+
+	debouncer.SendSignal()
+	scheduler.Poll(start.Add(500 * time.Millisecond))
+	debouncer.SendSignal()
+	scheduler.Poll(start.Add(1400 * time.Millisecond))
+	if *countPtr != 0 {
+		t.Errorf("Expected count %d, was %d", 0, *countPtr)
+	}
+
+	scheduler.Poll(start.Add(time.Hour))
+	scheduler.Poll(start)
+	if *countPtr != 1 {
+		t.Errorf("Expected count %d, was %d", 1, *countPtr)
+	}
+}
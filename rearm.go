@@ -0,0 +1,41 @@
+package godebouncer
+
+type rearm struct {
+	maxRearms int
+	onGiveUp  func(data any, err error)
+}
+
+// WithRearmOnFailure makes the debouncer retain the data and re-arm the wait duration when the triggered function attached by WithAnyResult() returns an error, instead of losing the data, and return the same instance of debouncer to use.
+// After maxRearms re-arms, the debouncer gives up and calls onGiveUp, if not nil, with the data and the last error. A signal received meanwhile is combined with the retained data by the reducer or the payload policy, as if the retained data was signaled first; if the policy keeps the new payload, the debouncer gives up on the retained data.
+func (d *Debouncer) WithRearmOnFailure(maxRearms int, onGiveUp func(data any, err error)) *Debouncer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.rearm = &rearm{maxRearms: maxRearms, onGiveUp: onGiveUp}
+	return d
+}
+
+// rearmOnFailure re-arms the failed call if it's allowed. If a new signal is already pending, the data of the failed call is folded into the pending call by the reducer or the payload policy instead. It returns whether the call has been re-armed, and the hook to call outside the mutex: onWaitStart on a re-arm, or onGiveUp if the debouncer gives up or the data is replaced by the pending signal. The caller must hold the mutex.
+func (d *Debouncer) rearmOnFailure(c *call, err error) (bool, func()) {
+	if err == nil || d.rearm == nil || d.closed {
+		return false, nil
+	}
+	giveUp := func() {}
+	if onGiveUp := d.rearm.onGiveUp; onGiveUp != nil {
+		giveUp = func() { onGiveUp(c.data, err) }
+	}
+	if c.rearms >= d.rearm.maxRearms {
+		return false, giveUp
+	}
+	if d.state == statePending {
+		if !d.retainFailedPayload(c) {
+			return false, giveUp
+		}
+		d.pending.rearms = max(d.pending.rearms, c.rearms+1)
+		return false, nil
+	}
+	c.rearms++
+	d.arm(c)
+	d.emit(EventArmed, c, nil)
+	return true, d.onWaitStart
+}
@@ -0,0 +1,141 @@
+package godebouncer_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+func TestRearmOnFailure(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	attempts := 0
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithRearmOnFailure(3, nil).WithAnyResult(func(data any) (any, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New("sink unavailable")
+		}
+		return data, nil
+	})
+
+	debouncer.SendSignalWithData("batch")
+	clock.Advance(2 * time.Second)
+	if attempts != 2 {
+		t.Errorf("Expected attempts %d, was %d", 2, attempts)
+	}
+	if !debouncer.Stats().Pending {
+		t.Error("Expected the failed batch to be re-armed")
+	}
+
+	clock.Advance(time.Second)
+	if attempts != 3 {
+		t.Errorf("Expected attempts %d, was %d", 3, attempts)
+	}
+	if err := debouncer.WaitOutstanding(context.Background()); err != nil {
+		t.Errorf("Expected nothing outstanding once the batch succeeded, got %v", err)
+	}
+}
+
+func TestRearmOnFailureGiveUp(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	attempts := 0
+	var givenUp any
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithRearmOnFailure(2, func(data any, err error) {
+		givenUp = data
+	}).WithAnyResult(func(data any) (any, error) {
+		attempts++
+		return nil, errors.New("sink unavailable")
+	})
+
+	debouncer.SendSignalWithData("batch")
+	clock.Advance(10 * time.Second)
+
+	if attempts != 3 {
+		t.Errorf("Expected attempts %d, was %d", 3, attempts)
+	}
+	if givenUp != "batch" {
+		t.Errorf("Expected to give up on %q, was %v", "batch", givenUp)
+	}
+}
+
+func TestRearmOnFailureHooks(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	attempts := 0
+	var hooks []string
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithRearmOnFailure(3, nil).WithOnWaitStart(func() {
+		hooks = append(hooks, "start")
+	}).WithOnWaitEnd(func(fired bool) {
+		hooks = append(hooks, "end")
+	}).WithAnyResult(func(data any) (any, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New("sink unavailable")
+		}
+		return data, nil
+	})
+	events := debouncer.Events()
+
+	debouncer.SendSignalWithData("batch")
+	clock.Advance(10 * time.Second)
+
+	expectedHooks := "[start end start end start end]"
+	if fmt.Sprint(hooks) != expectedHooks {
+		t.Errorf("Expected hooks %s, was %s", expectedHooks, fmt.Sprint(hooks))
+	}
+	expectedEvents := "[Armed@0 Firing@1000 Failed@1000 Armed@1000 Firing@2000 Failed@2000 Armed@2000 Firing@3000 Fired@3000]"
+	if received := fmt.Sprint(receiveEvents(events)); received != expectedEvents {
+		t.Errorf("Expected events %s, was %s", expectedEvents, received)
+	}
+}
+
+func TestRearmOnFailureWithPendingSignal(t *testing.T) {
+	testCases := []struct {
+		name             string
+		reduce           bool
+		expectedPayloads string
+		expectedGivenUp  any
+	}{
+		{name: "Reducer", reduce: true, expectedPayloads: "[[a] [a b]]", expectedGivenUp: nil},
+		{name: "LastWins", reduce: false, expectedPayloads: "[[a] [b]]", expectedGivenUp: []string{"a"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			clock := virtualtime.New(time.Unix(0, 0))
+			var payloads []any
+			var givenUp any
+			debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithRearmOnFailure(3, func(data any, err error) {
+				givenUp = data
+			}).WithTransform(func(data any) any {
+				return []string{data.(string)}
+			})
+			if testCase.reduce {
+				debouncer.WithReducer(func(pending, data any) any {
+					return append(pending.([]string), data.([]string)...)
+				})
+			}
+			debouncer.WithAnyResult(func(data any) (any, error) {
+				payloads = append(payloads, data)
+				if len(payloads) == 1 {
+					debouncer.SendSignalWithData("b")
+					return nil, errors.New("sink unavailable")
+				}
+				return data, nil
+			})
+
+			debouncer.SendSignalWithData("a")
+			clock.Advance(10 * time.Second)
+
+			if fmt.Sprint(payloads) != testCase.expectedPayloads {
+				t.Errorf("Expected payloads %s, was %s", testCase.expectedPayloads, fmt.Sprint(payloads))
+			}
+			if fmt.Sprint(givenUp) != fmt.Sprint(testCase.expectedGivenUp) {
+				t.Errorf("Expected to give up on %v, was %v", testCase.expectedGivenUp, givenUp)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package godebouncer
+
+import "errors"
+
+// Receipt tracks the outcome of one signal sent by SendSignalWithReceipt().
+type Receipt struct {
+	signal Signal
+	flight *flight
+}
+
+// SendSignalWithReceipt makes the same action as SendSignalWithData, and returns a receipt to confirm the delivery of this particular signal.
+func (d *Debouncer) SendSignalWithReceipt(anyVar any) (*Receipt, error) {
+	if !d.isAny {
+		return nil, errors.New(ErrorTypeIncorrectSendSignal)
+	}
+
+	c, err := d.signal(anyVar, d.callAny(anyVar))
+	if err != nil {
+		return nil, err
+	}
+	return &Receipt{signal: c.last, flight: c.flight}, nil
+}
+
+// Signal returns the metadata of the signal.
+func (r *Receipt) Signal() Signal {
+	return r.signal
+}
+
+// Done returns a receive-only channel which is closed when the trigger covering this signal completed, or when the signal has been cancelled.
+func (r *Receipt) Done() <-chan struct{} {
+	return r.flight.done
+}
+
+// Err returns the reason why the signal hasn't been delivered once Done() is closed: the error returned by the triggered function attached by WithAnyResult(), or ErrorTypeCancelled if the signal has been cancelled. It returns nil if the signal has been delivered or Done() isn't closed yet.
+func (r *Receipt) Err() error {
+	select {
+	case <-r.flight.done:
+		return r.flight.err
+	default:
+		return nil
+	}
+}
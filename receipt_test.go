@@ -0,0 +1,60 @@
+package godebouncer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+func TestReceiptResolvesWhenTriggerCompleted(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	var delivered []any
+	debouncer := godebouncer.New(time.Second).WithScheduler(clock).WithAny(func(data any) {
+		delivered = append(delivered, data)
+	})
+
+	first, _ := debouncer.SendSignalWithReceipt("a")
+	second, _ := debouncer.SendSignalWithReceipt("b")
+	select {
+	case <-first.Done():
+		t.Error("Expected the receipt to be pending before the trigger")
+	default:
+	}
+
+	clock.Advance(time.Second)
+	third, _ := debouncer.SendSignalWithReceipt("c")
+
+	for _, receipt := range []*godebouncer.Receipt{first, second} {
+		select {
+		case <-receipt.Done():
+		default:
+			t.Errorf("Expected the receipt of signal %d to be done", receipt.Signal().Seq)
+		}
+		if receipt.Err() != nil {
+			t.Errorf("Expected no error for signal %d, was %v", receipt.Signal().Seq, receipt.Err())
+		}
+	}
+
+	debouncer.Cancel()
+	<-third.Done()
+	if third.Err() == nil || third.Err().Error() != godebouncer.ErrorTypeCancelled {
+		t.Errorf("Expected error %q, was %v", godebouncer.ErrorTypeCancelled, third.Err())
+	}
+	if third.Signal().Seq != 3 {
+		t.Errorf("Expected seq %d, was %d", 3, third.Signal().Seq)
+	}
+	if len(delivered) != 1 {
+		t.Errorf("Expected count %d, was %d", 1, len(delivered))
+	}
+}
+
+func TestReceiptWithTriggered(t *testing.T) {
+	debouncer := godebouncer.New(time.Second).WithTriggered(func() {})
+
+	_, err := debouncer.SendSignalWithReceipt("a")
+	if err == nil || err.Error() != godebouncer.ErrorTypeIncorrectSendSignal {
+		t.Errorf("Expected error %q, was %v", godebouncer.ErrorTypeIncorrectSendSignal, err)
+	}
+}
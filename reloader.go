@@ -0,0 +1,115 @@
+package godebouncer
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/vnteamopen/godebouncer/internal/filewatch"
+)
+
+// ConfigReloader reloads a config file once per storm of changes: it debounces the change notifications, re-parses the file once, validates the config and applies it with the previous config.
+type ConfigReloader[C any] struct {
+	mu        sync.Mutex
+	reloadMu  sync.Mutex
+	path      string
+	debouncer *Debouncer
+	parse     func([]byte) (C, error)
+	validate  func(C) error
+	apply     func(old, new C)
+	onError   func(err error)
+	current   C
+}
+
+// NewConfigReloader creates a new instance of config reloader of the file at path, parsing the file with parse and calling apply with the previous and the new config once no change has been notified for the duration.
+func NewConfigReloader[C any](path string, duration time.Duration, parse func([]byte) (C, error), apply func(old, new C)) *ConfigReloader[C] {
+	r := &ConfigReloader[C]{path: path, parse: parse, apply: apply}
+	r.debouncer = New(duration).WithTriggered(func() {
+		if err := r.Reload(); err != nil && r.onError != nil {
+			r.onError(err)
+		}
+	})
+	return r
+}
+
+// WithValidate attached a function validating the new config before it's applied, and return the same instance of config reloader to use. An invalid config isn't applied.
+func (r *ConfigReloader[C]) WithValidate(validate func(C) error) *ConfigReloader[C] {
+	r.validate = validate
+	return r
+}
+
+// WithOnError attached a function called when a debounced reload fails to read, parse or validate the config, and return the same instance of config reloader to use. The current config is kept.
+func (r *ConfigReloader[C]) WithOnError(onError func(err error)) *ConfigReloader[C] {
+	r.onError = onError
+	return r
+}
+
+// WithScheduler replaces the scheduler used to wait for the duration, and return the same instance of config reloader to use.
+func (r *ConfigReloader[C]) WithScheduler(scheduler Scheduler) *ConfigReloader[C] {
+	r.debouncer.WithScheduler(scheduler)
+	return r
+}
+
+// Notify tells the config reloader that the file changed, e.g. from a file watcher or a SIGHUP handler. The file is reloaded once no change has been notified for the duration.
+func (r *ConfigReloader[C]) Notify() {
+	r.debouncer.SendSignal()
+}
+
+// Reload reads, parses, validates and applies the config immediately, e.g. to load the initial config. It returns the error of the first step that failed, then the current config is kept.
+func (r *ConfigReloader[C]) Reload() error {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	content, err := os.ReadFile(r.path)
+	if err != nil {
+		return err
+	}
+	config, err := r.parse(content)
+	if err != nil {
+		return err
+	}
+	if r.validate != nil {
+		if err := r.validate(config); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	old := r.current
+	r.current = config
+	r.mu.Unlock()
+
+	r.apply(old, config)
+	return nil
+}
+
+// Current returns the config applied last, or the zero value of C if none has been applied yet.
+func (r *ConfigReloader[C]) Current() C {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.current
+}
+
+// Watch checks the modification time and the size of the file every interval and notifies the changes, until the context is done, then cancels the pending reload and returns ctx.Err().
+func (r *ConfigReloader[C]) Watch(ctx context.Context, interval time.Duration) error {
+	defer r.debouncer.Cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last, _ := os.Stat(r.path)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			info, _ := os.Stat(r.path)
+			if filewatch.Changed(last, info) {
+				r.Notify()
+			}
+			last = info
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package godebouncer_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+func parsePort(content []byte) (int, error) {
+	return strconv.Atoi(strings.TrimSpace(string(content)))
+}
+
+func TestConfigReloaderNotify(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "port")
+	clock := virtualtime.New(time.Unix(0, 0))
+	var applied []string
+	var reloadErr error
+	reloader := godebouncer.NewConfigReloader(path, time.Second, parsePort, func(old, new int) {
+		applied = append(applied, fmt.Sprintf("%d->%d", old, new))
+	}).WithValidate(func(port int) error {
+		if port <= 0 {
+			return errors.New("invalid port")
+		}
+		return nil
+	}).WithOnError(func(err error) {
+		reloadErr = err
+	}).WithScheduler(clock)
+
+	os.WriteFile(path, []byte("8080\n"), 0o644)
+	if err := reloader.Reload(); err != nil {
+		t.Fatalf("Expected the initial config to load, got %v", err)
+	}
+
+	for _, content := range []string{"8081", "8082", "9090"} {
+		os.WriteFile(path, []byte(content), 0o644)
+		reloader.Notify()
+		clock.Advance(100 * time.Millisecond)
+	}
+	clock.Advance(time.Second)
+
+	os.WriteFile(path, []byte("-1"), 0o644)
+	reloader.Notify()
+	clock.Advance(time.Second)
+
+	if fmt.Sprint(applied) != "[0->8080 8080->9090]" {
+		t.Errorf("Expected applied configs %s, was %v", "[0->8080 8080->9090]", applied)
+	}
+	if reloader.Current() != 9090 {
+		t.Errorf("Expected current config %d, was %d", 9090, reloader.Current())
+	}
+	if reloadErr == nil || reloadErr.Error() != "invalid port" {
+		t.Errorf("Expected error %q, was %v", "invalid port", reloadErr)
+	}
+}
+
+func TestConfigReloaderWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "port")
+	os.WriteFile(path, []byte("8080"), 0o644)
+	applied := make(chan int, 10)
+	reloader := godebouncer.NewConfigReloader(path, 50*time.Millisecond, parsePort, func(old, new int) {
+		applied <- new
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go reloader.Watch(ctx, 10*time.Millisecond)
+
+	time.Sleep(30 * time.Millisecond)
+	os.WriteFile(path, []byte("8081"), 0o644)
+	os.WriteFile(path, []byte("80820"), 0o644)
+
+	select {
+	case port := <-applied:
+		if port != 80820 {
+			t.Errorf("Expected config %d, was %d", 80820, port)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the change to be reloaded")
+	}
+}
@@ -0,0 +1,99 @@
+package godebouncer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// flight is the outcome of a trigger, shared by all the signals covered by that trigger.
+type flight struct {
+	once   sync.Once
+	done   chan struct{}
+	result any
+	err    error
+}
+
+func newFlight() *flight {
+	return &flight{done: make(chan struct{})}
+}
+
+func (f *flight) resolve(result any, err error) {
+	f.once.Do(func() {
+		f.result, f.err = result, err
+		close(f.done)
+	})
+}
+
+// WithAnyResult attached a triggered function returning a result to debouncer instance and return the same instance of debouncer to use.
+// The result is shared with all the callers of SendSignalAndWait() covered by the same trigger, like x/sync/singleflight.
+func (d *Debouncer) WithAnyResult(triggeredFunc func(any) (any, error)) *Debouncer {
+	d.triggeredResultFunc = triggeredFunc
+	d.isAny = true
+	return d
+}
+
+// WithResultCache makes SendSignalAndWait() return the last successful result without sending a signal, if the result has been produced within the ttl, and return the same instance of debouncer to use.
+func (d *Debouncer) WithResultCache(ttl time.Duration) *Debouncer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.resultCacheTTL = ttl
+	d.cachedFlight = nil
+	return d
+}
+
+// SendSignalAndWait makes the same action as SendSignalWithData, unless a result is cached by WithResultCache(), then blocks until the trigger covering this signal finished and returns its result.
+// It returns an error if the signal is cancelled before the trigger, or ctx.Err() if the context is done first.
+func (d *Debouncer) SendSignalAndWait(ctx context.Context, anyVar any) (any, error) {
+	if !d.isAny {
+		return nil, errors.New(ErrorTypeIncorrectSendSignal)
+	}
+
+	if f := d.cachedResult(); f != nil {
+		return f.result, f.err
+	}
+
+	c, err := d.signal(anyVar, d.callAny(anyVar))
+	if err != nil {
+		return nil, err
+	}
+	f := c.flight
+	select {
+	case <-f.done:
+		return f.result, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// callAny returns the invocation of the triggered function with the data.
+func (d *Debouncer) callAny(anyVar any) func() (any, error) {
+	return func() (any, error) {
+		if d.triggeredResultFunc != nil {
+			return d.triggeredResultFunc(anyVar)
+		}
+		d.triggeredAnyFunc(anyVar)
+		return nil, nil
+	}
+}
+
+// cacheResult keeps the result of the flight for the result cache ttl if it succeeded. The caller must hold the mutex.
+func (d *Debouncer) cacheResult(f *flight) {
+	if d.resultCacheTTL > 0 && f.err == nil {
+		d.cachedFlight = f
+		d.cachedAt = d.scheduler.Now()
+	}
+}
+
+// cachedResult returns the flight of the cached result, or nil if there is none within the result cache ttl.
+func (d *Debouncer) cachedResult() *flight {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.cachedFlight == nil || d.scheduler.Now().Sub(d.cachedAt) >= d.resultCacheTTL {
+		return nil
+	}
+	return d.cachedFlight
+}
@@ -0,0 +1,101 @@
+package godebouncer_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+func TestSendSignalAndWaitSharesResult(t *testing.T) {
+	var calls int32
+	debouncer := godebouncer.New(100 * time.Millisecond).WithAnyResult(func(data any) (any, error) {
+		atomic.AddInt32(&calls, 1)
+		return fmt.Sprintf("result of %v", data), nil
+	})
+	expectedCalls := int32(1)
+
+	var wg sync.WaitGroup
+	results := make([]any, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = debouncer.SendSignalAndWait(context.Background(), "config")
+		}(i)
+	}
+	wg.Wait()
+
+	if atomic.LoadInt32(&calls) != expectedCalls {
+		t.Errorf("Expected calls %d, was %d", expectedCalls, atomic.LoadInt32(&calls))
+	}
+	for _, result := range results {
+		if result != "result of config" {
+			t.Errorf("Expected result %q, was %v", "result of config", result)
+		}
+	}
+}
+
+func TestSendSignalAndWaitCancelled(t *testing.T) {
+	debouncer := godebouncer.New(time.Hour).WithAnyResult(func(data any) (any, error) {
+		return data, nil
+	})
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		debouncer.Cancel()
+	}()
+
+	_, err := debouncer.SendSignalAndWait(context.Background(), "config")
+	if err == nil || err.Error() != godebouncer.ErrorTypeCancelled {
+		t.Errorf("Expected error %q, got %v", godebouncer.ErrorTypeCancelled, err)
+	}
+}
+
+func TestSendSignalAndWaitMisconfiguration(t *testing.T) {
+	debouncer := godebouncer.New(time.Hour).WithTriggered(func() {})
+
+	if _, err := debouncer.SendSignalAndWait(context.Background(), "config"); err == nil {
+		t.Error("Error not returned")
+	}
+}
+
+func TestResultCache(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	calls := 0
+	debouncer := godebouncer.New(100 * time.Millisecond).WithScheduler(clock).WithResultCache(time.Second).WithAnyResult(func(data any) (any, error) {
+		calls++
+		return calls, nil
+	})
+
+	go func() {
+		for debouncer.Stats().Signals == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		clock.Advance(100 * time.Millisecond)
+	}()
+	first, _ := debouncer.SendSignalAndWait(context.Background(), nil)
+
+	clock.Advance(500 * time.Millisecond)
+	cached, _ := debouncer.SendSignalAndWait(context.Background(), nil)
+	if cached != first {
+		t.Errorf("Expected cached result %v, was %v", first, cached)
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	go func() {
+		for debouncer.Stats().Signals == 1 {
+			time.Sleep(time.Millisecond)
+		}
+		clock.Advance(100 * time.Millisecond)
+	}()
+	refreshed, _ := debouncer.SendSignalAndWait(context.Background(), nil)
+	if refreshed != 2 {
+		t.Errorf("Expected refreshed result %d after the ttl, was %v", 2, refreshed)
+	}
+}
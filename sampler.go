@@ -0,0 +1,57 @@
+package godebouncer
+
+import (
+	"context"
+	"time"
+)
+
+// Sampler polls a value every interval and calls onStable once the sampled value has been stable for the wait duration, e.g. for sensors or external APIs.
+type Sampler[T comparable] struct {
+	interval time.Duration
+	duration time.Duration
+	sample   func() T
+	onStable func(T)
+	equal    func(reference, sampled T) bool
+}
+
+// NewSampler creates a new instance of sampler calling sample every interval, and onStable with the value once it has been stable for the duration.
+func NewSampler[T comparable](interval, duration time.Duration, sample func() T, onStable func(T)) *Sampler[T] {
+	return &Sampler[T]{
+		interval: interval,
+		duration: duration,
+		sample:   sample,
+		onStable: onStable,
+		equal:    func(reference, sampled T) bool { return reference == sampled },
+	}
+}
+
+// WithTolerance replaces how the sampled values are compared to the value the stable period started with, and return the same instance of sampler to use.
+func (s *Sampler[T]) WithTolerance(equal func(reference, sampled T) bool) *Sampler[T] {
+	s.equal = equal
+	return s
+}
+
+// Run polls the value until the context is done, then cancels the pending call of onStable and returns ctx.Err().
+func (s *Sampler[T]) Run(ctx context.Context) error {
+	debouncer := New(s.duration).WithAny(func(value any) {
+		s.onStable(value.(T))
+	})
+	defer debouncer.Cancel()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	reference := s.sample()
+	debouncer.SendSignalWithData(reference)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if sampled := s.sample(); !s.equal(reference, sampled) {
+				reference = sampled
+				debouncer.SendSignalWithData(reference)
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package godebouncer_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+)
+
+func TestSampler(t *testing.T) {
+	var reading int64 = 10
+	var mu sync.Mutex
+	var stable []int64
+	sampler := godebouncer.NewSampler(10*time.Millisecond, 100*time.Millisecond, func() int64 {
+		return atomic.LoadInt64(&reading)
+	}, func(value int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		stable = append(stable, value)
+	}).WithTolerance(func(reference, sampled int64) bool {
+		return sampled-reference < 5 && reference-sampled < 5
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- sampler.Run(ctx)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	atomic.StoreInt64(&reading, 20)
+	time.Sleep(50 * time.Millisecond)
+	atomic.StoreInt64(&reading, 22)
+	time.Sleep(200 * time.Millisecond)
+	cancel()
+
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Expected error %v, got %v", context.Canceled, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	expectedStable := "[20]"
+	if fmt.Sprint(stable) != expectedStable {
+		t.Errorf("Expected stable values %s, was %s", expectedStable, fmt.Sprint(stable))
+	}
+}
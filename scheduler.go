@@ -0,0 +1,21 @@
+package godebouncer
+
+import "time"
+
+// Scheduler tells the time and runs functions after a duration. The default scheduler of a debouncer uses the time package.
+type Scheduler interface {
+	// Now returns the current time.
+	Now() time.Time
+	// AfterFunc waits for the duration to elapse and then calls f. It returns a function which stops the call and reports whether it was stopped before running, like time.Timer.Stop().
+	AfterFunc(duration time.Duration, f func()) (stop func() bool)
+}
+
+type timeScheduler struct{}
+
+func (timeScheduler) Now() time.Time {
+	return time.Now()
+}
+
+func (timeScheduler) AfterFunc(duration time.Duration, f func()) func() bool {
+	return time.AfterFunc(duration, f).Stop
+}
@@ -0,0 +1,41 @@
+//go:build js && wasm
+
+package godebouncer
+
+import (
+	"sync/atomic"
+	"syscall/js"
+	"time"
+)
+
+// JSScheduler is a scheduler for browser-compiled Go which waits for the durations with setTimeout of the JavaScript host, so rearming on each keystroke only clears and sets a JavaScript timeout.
+// The functions run in a new goroutine when the timeout fires, since a blocking call in a JavaScript callback would deadlock.
+type JSScheduler struct{}
+
+// Now returns the current time.
+func (JSScheduler) Now() time.Time {
+	return time.Now()
+}
+
+// AfterFunc calls f once the duration elapsed, using setTimeout.
+func (JSScheduler) AfterFunc(duration time.Duration, f func()) func() bool {
+	var finished atomic.Bool
+	var callback js.Func
+	callback = js.FuncOf(func(this js.Value, args []js.Value) any {
+		if finished.CompareAndSwap(false, true) {
+			callback.Release()
+			go f()
+		}
+		return nil
+	})
+	id := js.Global().Call("setTimeout", callback, duration.Milliseconds())
+
+	return func() bool {
+		if !finished.CompareAndSwap(false, true) {
+			return false
+		}
+		js.Global().Call("clearTimeout", id)
+		callback.Release()
+		return true
+	}
+}
@@ -0,0 +1,24 @@
+//go:build js && wasm
+
+package godebouncer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+)
+
+func TestJSScheduler(t *testing.T) {
+	countPtr, incrementCount := createIncrementCount(0)
+	debouncer := godebouncer.New(50 * time.Millisecond).WithScheduler(godebouncer.JSScheduler{}).WithTriggered(incrementCount)
+
+	debouncer.SendSignal()
+	time.Sleep(20 * time.Millisecond)
+	debouncer.SendSignal()
+	<-debouncer.Done()
+
+	if *countPtr != 1 {
+		t.Errorf("Expected count %d, was %d", 1, *countPtr)
+	}
+}
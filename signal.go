@@ -0,0 +1,72 @@
+package godebouncer
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+)
+
+// Flusher is implemented by anything that can invoke its pending work immediately, e.g. *Debouncer.
+type Flusher interface {
+	Flush()
+}
+
+// FlushOnSignal blocks until one of the signals is received, then flushes all flushers concurrently and waits at most the timeout for them to finish.
+// It returns ctx.Err() if the context is done before any signal is received, or context.DeadlineExceeded if the flushers don't finish in time.
+func FlushOnSignal(ctx context.Context, timeout time.Duration, sigs []os.Signal, flushers ...Flusher) error {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+	defer signal.Stop(ch)
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-ch:
+	}
+
+	var wg sync.WaitGroup
+	for _, flusher := range flushers {
+		wg.Add(1)
+		go func(flusher Flusher) {
+			defer wg.Done()
+			flusher.Flush()
+		}(flusher)
+	}
+
+	flushed := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(flushed)
+	}()
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	select {
+	case <-flushed:
+		return nil
+	case <-drainCtx.Done():
+		return drainCtx.Err()
+	}
+}
+
+// OnSignal subscribes to the signals and calls f once the signals stop arriving for the wait duration, instead of once per signal, e.g. to reload on bursts of SIGHUP.
+// It blocks until the context is done, cancels the pending call and returns ctx.Err().
+func OnSignal(ctx context.Context, duration time.Duration, f func(), sigs ...os.Signal) error {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+	defer signal.Stop(ch)
+
+	debouncer := New(duration).WithTriggered(f)
+	defer debouncer.Cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ch:
+			debouncer.SendSignal()
+		}
+	}
+}
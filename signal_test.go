@@ -0,0 +1,90 @@
+//go:build !windows && !js
+
+package godebouncer_test
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+)
+
+func TestFlushOnSignal(t *testing.T) {
+	countPtr, incrementCount := createIncrementCount(0)
+	debouncer := godebouncer.New(time.Hour).WithTriggered(incrementCount)
+	expectedCounter := int(1)
+
+	debouncer.SendSignal()
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+	}()
+
+	err := godebouncer.FlushOnSignal(context.Background(), time.Second, []os.Signal{syscall.SIGUSR1}, debouncer)
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if *countPtr != expectedCounter {
+		t.Errorf("Expected count %d, was %d", expectedCounter, *countPtr)
+	}
+}
+
+func TestFlushOnSignalTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	debouncer := godebouncer.New(time.Hour).WithTriggered(func() {
+		<-release
+	})
+
+	debouncer.SendSignal()
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+	}()
+
+	err := godebouncer.FlushOnSignal(context.Background(), 100*time.Millisecond, []os.Signal{syscall.SIGUSR1}, debouncer)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected error %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestFlushOnSignalContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := godebouncer.FlushOnSignal(ctx, time.Second, []os.Signal{syscall.SIGUSR1})
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected error %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestOnSignal(t *testing.T) {
+	var reloads int32
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+	go func() {
+		errCh <- godebouncer.OnSignal(ctx, 100*time.Millisecond, func() {
+			atomic.AddInt32(&reloads, 1)
+		}, syscall.SIGUSR2)
+	}()
+	expectedReloads := int32(1)
+
+	time.Sleep(50 * time.Millisecond)
+	for i := 0; i < 5; i++ {
+		syscall.Kill(os.Getpid(), syscall.SIGUSR2)
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(300 * time.Millisecond)
+	cancel()
+
+	if err := <-errCh; err != context.Canceled {
+		t.Errorf("Expected error %v, got %v", context.Canceled, err)
+	}
+	if atomic.LoadInt32(&reloads) != expectedReloads {
+		t.Errorf("Expected reloads %d, was %d", expectedReloads, atomic.LoadInt32(&reloads))
+	}
+}
@@ -0,0 +1,115 @@
+package godebouncer
+
+import (
+	"sync"
+	"time"
+)
+
+// Edge is a direction of the transitions of a state debouncer, as classified by the function passed to WithEdges().
+type Edge int
+
+const (
+	// EdgeRising is a transition classified as rising.
+	EdgeRising Edge = 1 << iota
+	// EdgeFalling is a transition not classified as rising.
+	EdgeFalling
+	// EdgeBoth is any transition.
+	EdgeBoth = EdgeRising | EdgeFalling
+)
+
+// StateDebouncer debounces a state: the callback is only invoked when a new value has remained stable for the wait duration, e.g. for flapping health checks or connectivity flags.
+type StateDebouncer[T comparable] struct {
+	durationFor func(oldValue, newValue T) time.Duration
+	isRising    func(oldValue, newValue T) bool
+	edges       Edge
+	onChange    func(oldValue, newValue T)
+	debouncer   *Debouncer
+	mu          sync.Mutex
+	value       T
+	target      T
+}
+
+// NewStateDebouncer creates a new instance of state debouncer starting with the initial value, which calls onChange with the old and new values when a new value has remained stable for the duration.
+func NewStateDebouncer[T comparable](initial T, duration time.Duration, onChange func(oldValue, newValue T)) *StateDebouncer[T] {
+	s := &StateDebouncer[T]{
+		durationFor: func(T, T) time.Duration { return duration },
+		edges:       EdgeBoth,
+		onChange:    onChange,
+		value:       initial,
+		target:      initial,
+	}
+	s.debouncer = New(duration).WithTriggered(s.change)
+	return s
+}
+
+// WithEdges classifies the transitions from the old to the new value as rising or falling, with an independent stabilization duration for each direction, and return the same instance of state debouncer to use.
+func (s *StateDebouncer[T]) WithEdges(isRising func(oldValue, newValue T) bool, risingDuration, fallingDuration time.Duration) *StateDebouncer[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.isRising = isRising
+	s.durationFor = func(oldValue, newValue T) time.Duration {
+		if isRising(oldValue, newValue) {
+			return risingDuration
+		}
+		return fallingDuration
+	}
+	return s
+}
+
+// WithEdgeFilter makes the state debouncer only invoke onChange for the transitions of the edges, and return the same instance of state debouncer to use.
+// The other transitions still update the debounced value. Without WithEdges(), every transition is rising.
+func (s *StateDebouncer[T]) WithEdgeFilter(edges Edge) *StateDebouncer[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.edges = edges
+	return s
+}
+
+// Set reports the current raw value. A value different from the debounced one starts the wait duration, unless the same value is already waiting; the debounced value cancels the wait.
+func (s *StateDebouncer[T]) Set(value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if value == s.value {
+		s.target = value
+		s.debouncer.Cancel()
+		return
+	}
+	if value == s.target {
+		return
+	}
+
+	s.target = value
+	s.debouncer.UpdateTimeDuration(s.durationFor(s.value, value))
+	s.debouncer.SendSignal()
+}
+
+// Value returns the debounced value.
+func (s *StateDebouncer[T]) Value() T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.value
+}
+
+func (s *StateDebouncer[T]) change() {
+	s.mu.Lock()
+	if s.target == s.value {
+		s.mu.Unlock()
+		return
+	}
+	oldValue := s.value
+	s.value = s.target
+	newValue := s.value
+	edge := EdgeRising
+	if s.isRising != nil && !s.isRising(oldValue, newValue) {
+		edge = EdgeFalling
+	}
+	edges := s.edges
+	s.mu.Unlock()
+
+	if edges&edge != 0 {
+		s.onChange(oldValue, newValue)
+	}
+}
@@ -0,0 +1,80 @@
+package godebouncer_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+)
+
+func TestStateDebouncer(t *testing.T) {
+	var mu sync.Mutex
+	var changes []string
+	state := godebouncer.NewStateDebouncer("up", 100*time.Millisecond, func(oldValue, newValue string) {
+		mu.Lock()
+		defer mu.Unlock()
+		changes = append(changes, oldValue+"->"+newValue)
+	})
+
+	state.Set("down")
+	time.Sleep(50 * time.Millisecond)
+	state.Set("up")
+	time.Sleep(150 * time.Millisecond)
+	if state.Value() != "up" {
+		t.Errorf("Expected value %q when flapping, was %q", "up", state.Value())
+	}
+
+	state.Set("down")
+	time.Sleep(50 * time.Millisecond)
+	state.Set("degraded")
+	time.Sleep(50 * time.Millisecond)
+	state.Set("degraded")
+	time.Sleep(100 * time.Millisecond)
+	if state.Value() != "degraded" {
+		t.Errorf("Expected value %q once stable, was %q", "degraded", state.Value())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	expectedChanges := "[up->degraded]"
+	if fmt.Sprint(changes) != expectedChanges {
+		t.Errorf("Expected changes %s, was %s", expectedChanges, fmt.Sprint(changes))
+	}
+}
+
+func TestStateDebouncerEdges(t *testing.T) {
+	var mu sync.Mutex
+	var changes []string
+	state := godebouncer.NewStateDebouncer(true, time.Hour, func(oldValue, newValue bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		changes = append(changes, fmt.Sprintf("%t->%t", oldValue, newValue))
+	}).WithEdges(func(oldValue, newValue bool) bool {
+		return newValue
+	}, 0, 100*time.Millisecond).WithEdgeFilter(godebouncer.EdgeFalling)
+
+	state.Set(false)
+	time.Sleep(50 * time.Millisecond)
+	if !state.Value() {
+		t.Error("Expected the falling transition to wait for its duration")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if state.Value() {
+		t.Error("Expected the falling transition after its duration")
+	}
+
+	state.Set(true)
+	time.Sleep(20 * time.Millisecond)
+	if !state.Value() {
+		t.Error("Expected the rising transition to be immediate")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	expectedChanges := "[true->false]"
+	if fmt.Sprint(changes) != expectedChanges {
+		t.Errorf("Expected changes %s, was %s", expectedChanges, fmt.Sprint(changes))
+	}
+}
@@ -0,0 +1,108 @@
+package godebouncer
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Tiered invokes several callbacks at increasing quiet thresholds of the same signals, e.g. a preview after 300 milliseconds of quiet, a full rebuild after 3 seconds and a cleanup after 1 minute.
+// It uses one timer at a time, and the callbacks always run in the order of their thresholds.
+type Tiered struct {
+	mu            sync.Mutex
+	scheduler     Scheduler
+	tiers         []tier
+	next          int
+	lastSignal    time.Time
+	generation    uint64
+	stopTimerFunc func() bool
+}
+
+type tier struct {
+	threshold time.Duration
+	callback  func()
+}
+
+// NewTiered creates a new instance of tiered debouncer without any tier.
+func NewTiered() *Tiered {
+	return &Tiered{scheduler: timeScheduler{}}
+}
+
+// WithTier attached a callback invoked once no signal has been sent for the threshold, and return the same instance of tiered debouncer to use.
+func (t *Tiered) WithTier(threshold time.Duration, callback func()) *Tiered {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tiers = append(t.tiers, tier{threshold: threshold, callback: callback})
+	sort.SliceStable(t.tiers, func(i, j int) bool {
+		return t.tiers[i].threshold < t.tiers[j].threshold
+	})
+	return t
+}
+
+// WithScheduler replaces the scheduler used to wait for the thresholds, and return the same instance of tiered debouncer to use.
+func (t *Tiered) WithScheduler(scheduler Scheduler) *Tiered {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.scheduler = scheduler
+	return t
+}
+
+// SendSignal restarts the quiet period of all tiers.
+func (t *Tiered) SendSignal() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stopTimer()
+	t.generation++
+	t.next = 0
+	t.lastSignal = t.scheduler.Now()
+	t.armNext()
+}
+
+// Cancel cancels the callbacks of the tiers which haven't been invoked since the last SendSignal().
+func (t *Tiered) Cancel() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.stopTimer()
+	t.generation++
+}
+
+// armNext schedules the next tier at its threshold since the last signal. The caller must hold the mutex.
+func (t *Tiered) armNext() {
+	if t.next >= len(t.tiers) {
+		return
+	}
+	generation := t.generation
+	wait := t.lastSignal.Add(t.tiers[t.next].threshold).Sub(t.scheduler.Now())
+	t.stopTimerFunc = t.scheduler.AfterFunc(wait, func() {
+		t.expire(generation)
+	})
+}
+
+func (t *Tiered) expire(generation uint64) {
+	t.mu.Lock()
+	if t.generation != generation || t.next >= len(t.tiers) {
+		t.mu.Unlock()
+		return
+	}
+	callback := t.tiers[t.next].callback
+	t.mu.Unlock()
+
+	callback()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.generation == generation {
+		t.next++
+		t.armNext()
+	}
+}
+
+func (t *Tiered) stopTimer() {
+	if t.stopTimerFunc != nil {
+		t.stopTimerFunc()
+	}
+}
@@ -0,0 +1,41 @@
+package godebouncer_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+func TestTiered(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	var calls []string
+	record := func(name string) func() {
+		return func() {
+			calls = append(calls, fmt.Sprintf("%s@%d", name, clock.Now().UnixMilli()))
+		}
+	}
+	tiered := godebouncer.NewTiered().WithScheduler(clock).
+		WithTier(3*time.Second, record("rebuild")).
+		WithTier(300*time.Millisecond, record("preview")).
+		WithTier(time.Minute, record("cleanup"))
+
+	tiered.SendSignal()
+	clock.Advance(time.Second)
+	tiered.SendSignal()
+	clock.Advance(time.Minute)
+	tiered.SendSignal()
+	clock.Advance(time.Second)
+	tiered.Cancel()
+	clock.Advance(time.Minute)
+
+	expectedCalls := "[preview@300 preview@1300 rebuild@4000 cleanup@61000 preview@61300]"
+	if fmt.Sprint(calls) != expectedCalls {
+		t.Errorf("Expected calls %s, was %s", expectedCalls, fmt.Sprint(calls))
+	}
+	if clock.Pending() != 0 {
+		t.Errorf("Expected no pending timer, was %d", clock.Pending())
+	}
+}
@@ -0,0 +1,11 @@
+package godebouncer
+
+// WithTransform attached a function applied to the payload of each SendSignalWithData() accepted by the filter, before it's retained by the payload policy or the reducer, and return the same instance of debouncer to use.
+// Together with WithFilter() and WithReducer(), it makes a filter, map and reduce pipeline of the payloads inside the debouncer.
+func (d *Debouncer) WithTransform(transform func(any) any) *Debouncer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.transform = transform
+	return d
+}
@@ -0,0 +1,83 @@
+package godebouncer
+
+import (
+	"sync"
+	"time"
+)
+
+// Watchdog is the mirror image of a debouncer, e.g. for heartbeat monitoring: SendSignal() is a keepalive, and the alarm is raised when no signal has been sent for the wait duration.
+// With WithRepeat(), the alarm is raised again every interval until the signals resume.
+type Watchdog struct {
+	mu         sync.Mutex
+	debouncer  *Debouncer
+	scheduler  Scheduler
+	repeat     time.Duration
+	onAlarm    func(silence time.Duration)
+	lastSignal time.Time
+}
+
+// NewWatchdog creates a new instance of watchdog calling onAlarm with the time since the last signal once no signal has been sent for the duration.
+func NewWatchdog(duration time.Duration, onAlarm func(silence time.Duration)) *Watchdog {
+	w := &Watchdog{scheduler: timeScheduler{}, onAlarm: onAlarm}
+	w.debouncer = New(duration).WithAny(func(any) {
+		w.alarm()
+	})
+	return w
+}
+
+// WithRepeat makes the watchdog raise the alarm again every interval until a signal is sent, and return the same instance of watchdog to use.
+func (w *Watchdog) WithRepeat(interval time.Duration) *Watchdog {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.repeat = interval
+	return w
+}
+
+// WithScheduler replaces the scheduler used to wait for the duration, and return the same instance of watchdog to use.
+func (w *Watchdog) WithScheduler(scheduler Scheduler) *Watchdog {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.scheduler = scheduler
+	w.debouncer.WithScheduler(scheduler)
+	return w
+}
+
+// Start arms the watchdog without a signal, so the alarm is raised if the first signal never comes.
+func (w *Watchdog) Start() error {
+	return w.SendSignal()
+}
+
+// SendSignal is a keepalive: it postpones the alarm for the wait duration.
+func (w *Watchdog) SendSignal() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.lastSignal = w.scheduler.Now()
+	return w.debouncer.SendSignalWithData(nil)
+}
+
+// Stop disarms the watchdog until the next signal. It returns true if an alarm was pending.
+func (w *Watchdog) Stop() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.debouncer.Cancel()
+}
+
+// alarm raises the alarm, and re-arms the watchdog for the repeat interval, unless a signal has been sent since the wait duration elapsed.
+func (w *Watchdog) alarm() {
+	w.mu.Lock()
+	if w.debouncer.Stats().Pending {
+		w.mu.Unlock()
+		return
+	}
+	silence := w.scheduler.Now().Sub(w.lastSignal)
+	if w.repeat > 0 {
+		w.debouncer.SendSignalWithDelay(w.repeat, nil)
+	}
+	w.mu.Unlock()
+
+	w.onAlarm(silence)
+}
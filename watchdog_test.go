@@ -0,0 +1,57 @@
+package godebouncer_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vnteamopen/godebouncer"
+	"github.com/vnteamopen/godebouncer/internal/virtualtime"
+)
+
+func TestWatchdog(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	var alarms []time.Duration
+	watchdog := godebouncer.NewWatchdog(time.Second, func(silence time.Duration) {
+		alarms = append(alarms, silence)
+	}).WithScheduler(clock)
+
+	watchdog.Start()
+	for i := 0; i < 5; i++ {
+		clock.Advance(500 * time.Millisecond)
+		watchdog.SendSignal()
+	}
+	if len(alarms) != 0 {
+		t.Errorf("Expected count %d, was %d", 0, len(alarms))
+	}
+
+	clock.Advance(5 * time.Second)
+	if fmt.Sprint(alarms) != "[1s]" {
+		t.Errorf("Expected alarms %s, was %v", "[1s]", alarms)
+	}
+}
+
+func TestWatchdogRepeat(t *testing.T) {
+	clock := virtualtime.New(time.Unix(0, 0))
+	var alarms []time.Duration
+	watchdog := godebouncer.NewWatchdog(time.Second, func(silence time.Duration) {
+		alarms = append(alarms, silence)
+	}).WithRepeat(2 * time.Second).WithScheduler(clock)
+
+	watchdog.Start()
+	clock.Advance(5 * time.Second)
+	watchdog.SendSignal()
+	clock.Advance(900 * time.Millisecond)
+
+	if fmt.Sprint(alarms) != "[1s 3s 5s]" {
+		t.Errorf("Expected alarms %s, was %v", "[1s 3s 5s]", alarms)
+	}
+
+	if !watchdog.Stop() {
+		t.Error("Expected a pending alarm to stop")
+	}
+	clock.Advance(5 * time.Second)
+	if len(alarms) != 3 {
+		t.Errorf("Expected count %d, was %d", 3, len(alarms))
+	}
+}